@@ -0,0 +1,15 @@
+// Command glide calls the Glide APIs from the command line
+//
+// It is meant for support and operations work: checking a SIM swap or a number by
+// hand, or running a CSV of numbers through an API. Run "glide -h" for the commands.
+package main
+
+import (
+	"os"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Main())
+}
@@ -0,0 +1,123 @@
+package glide
+
+import (
+	"net/http"
+	"time"
+)
+
+// CallOption is a functional option for configuring a single API call
+// Call options override the client Config for that call only
+type CallOption func(*callOptions)
+
+// callOptions holds the effective settings for a single call
+type callOptions struct {
+	timeout        time.Duration // Deadline for the whole call including retries, 0 for none
+	retryCount     int
+	retryDelay     time.Duration
+	idempotencyKey string
+	headers        http.Header
+	dataResidency  string        // Overrides Config.DataResidency
+	meta           *ResponseMeta // Filled in when the call finishes, if requested
+
+	// Collected while the call runs
+	attempts      int
+	rateLimitWait time.Duration
+	status        int
+	header        http.Header
+
+	endpoint          *endpointState // Endpoint of the latest attempt
+	apiKey            string         // Key sent with the latest attempt
+	credentialRetried bool           // Whether the call was already retried after a key rotation
+}
+
+// WithCallTimeout bounds the whole call, including rate limiting and retries
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithCallRetry overrides the number of retries for this call
+// Use 0 to disable retries
+func WithCallRetry(count int) CallOption {
+	return func(o *callOptions) {
+		o.retryCount = count
+	}
+}
+
+// WithCallRetryDelay overrides the base delay between retries for this call
+func WithCallRetryDelay(delay time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.retryDelay = delay
+	}
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header sent with this call
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithHeader adds an HTTP header to this call
+// SDK-managed headers (Content-Type, Accept, User-Agent) cannot be overridden.
+// An Idempotency-Key header is the same as WithIdempotencyKey.
+func WithHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if http.CanonicalHeaderKey(key) == "Idempotency-Key" {
+			o.idempotencyKey = value
+			return
+		}
+		if o.headers == nil {
+			o.headers = make(http.Header)
+		}
+		o.headers.Add(key, value)
+	}
+}
+
+// WithCallDataResidency pins this call to the endpoints that list country, e.g. "DE"
+func WithCallDataResidency(country string) CallOption {
+	return func(o *callOptions) {
+		o.dataResidency = country
+	}
+}
+
+// WithResponseMeta fills meta with the HTTP metadata of the call once it returns
+// meta is populated for both successful and failed calls that reached doRequest
+func WithResponseMeta(meta *ResponseMeta) CallOption {
+	return func(o *callOptions) {
+		o.meta = meta
+	}
+}
+
+// newCallOptions applies call options on top of the client defaults
+func (c *Client) newCallOptions(opts []CallOption) *callOptions {
+	o := &callOptions{
+		retryCount:    c.config.RetryCount,
+		retryDelay:    c.config.RetryDelay,
+		dataResidency: c.config.DataResidency,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.retryCount < 0 {
+		o.retryCount = 0
+	}
+	return o
+}
+
+// applyHeaders sets the per-call headers on a request
+func (o *callOptions) applyHeaders(req *http.Request) {
+	for key, values := range o.headers {
+		switch http.CanonicalHeaderKey(key) {
+		case "Content-Type", "Accept", "User-Agent":
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if o.idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", o.idempotencyKey)
+	}
+}
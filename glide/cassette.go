@@ -0,0 +1,395 @@
+package glide
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a Cassette records or replays interactions
+type CassetteMode int
+
+const (
+	// CassetteReplay serves recorded responses and fails requests that have no recording
+	CassetteReplay CassetteMode = iota
+
+	// CassetteRecord forwards requests to the API and records the sanitized interactions
+	CassetteRecord
+)
+
+// cassetteVersion is the version of the cassette file format
+const cassetteVersion = 1
+
+// cassettePlainFields contain a sensitive keyword but never hold secrets, so they are kept for replay
+var cassettePlainFields = map[string]bool{
+	"authentication_strategy": true,
+}
+
+// cassetteSkippedHeaders are response headers that are not recorded
+var cassetteSkippedHeaders = map[string]bool{
+	"Content-Length": true,
+	"Date":           true,
+	"Set-Cookie":     true,
+}
+
+// ParseCassetteMode converts a string such as "record" or "replay" to a CassetteMode
+// Anything other than "record" replays, so CI never reaches the network by accident
+func ParseCassetteMode(mode string) CassetteMode {
+	if strings.EqualFold(strings.TrimSpace(mode), "record") {
+		return CassetteRecord
+	}
+	return CassetteReplay
+}
+
+// Cassette is an http.RoundTripper that records API interactions to a file and replays them
+//
+// Recorded requests and responses are sanitized with the same rules as log output:
+// the API key is dropped, credentials and tokens are redacted, phone numbers and emails
+// are masked, and KYC identity values are redacted. Requests are matched on method, path
+// and sanitized body, ignoring the random nonce sent by MagicAuth.Prepare. With
+// WithCassetteMatchKey, bodies are also matched on an HMAC of the original body, so requests
+// that only differ in masked values do not share a recording. The key is never recorded.
+type Cassette struct {
+	path      string
+	mode      CassetteMode
+	transport http.RoundTripper
+	matchKey  []byte
+
+	mu           sync.Mutex
+	interactions []cassetteInteraction
+	used         []bool
+	unmatched    []string
+}
+
+// cassetteFile is the on-disk cassette format
+type cassetteFile struct {
+	Version      int                   `json:"version"`
+	Interactions []cassetteInteraction `json:"interactions"`
+}
+
+// cassetteInteraction is a recorded request and its response
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	BodyHMAC string          `json:"body_hmac,omitempty"` // HMAC-SHA256 of the unsanitized body without the nonce
+}
+
+type cassetteResponse struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   json.RawMessage   `json:"body,omitempty"`
+	Text   string            `json:"text,omitempty"` // Body that is not JSON
+}
+
+// CassetteOption is a functional option for configuring a Cassette
+type CassetteOption func(*Cassette)
+
+// WithCassetteMatchKey matches request bodies on their HMAC under key, e.g. a CI secret
+// Recording and replaying must use the same key; without one the sanitized body is matched
+func WithCassetteMatchKey(key []byte) CassetteOption {
+	return func(c *Cassette) {
+		c.matchKey = key
+	}
+}
+
+// NewCassette creates a cassette backed by the file at path
+// In replay mode the file must exist; in record mode it is written by Close
+// transport is used to reach the API when recording, http.DefaultTransport if nil
+func NewCassette(path string, mode CassetteMode, transport http.RoundTripper, opts ...CassetteOption) (*Cassette, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	c := &Cassette{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if mode == CassetteRecord {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	if file.Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d in %s", file.Version, path)
+	}
+	c.interactions = file.Interactions
+	c.used = make([]bool, len(file.Interactions))
+	return c, nil
+}
+
+// HTTPClient returns an HTTP client using the cassette, for use with WithHTTPClient
+func (c *Cassette) HTTPClient() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// RoundTrip records or replays a single request
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var raw []byte
+	if req.Body != nil {
+		var err error
+		raw, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	recordedReq := cassetteRequest{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Body:     sanitizeCassetteRequestBody(req.URL.Path, raw),
+		BodyHMAC: c.bodyHMAC(raw),
+	}
+
+	if c.mode == CassetteRecord {
+		return c.record(req, raw, recordedReq)
+	}
+	return c.replay(req, recordedReq)
+}
+
+// record forwards the request and stores the sanitized interaction
+func (c *Cassette) record(req *http.Request, raw []byte, recordedReq cassetteRequest) (*http.Response, error) {
+	forwarded := req.Clone(req.Context())
+	forwarded.Body = io.NopCloser(bytes.NewReader(raw))
+	resp, err := c.transport.RoundTrip(forwarded)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	recordedResp := cassetteResponse{
+		Status: resp.StatusCode,
+		Header: make(map[string]string),
+	}
+	for name := range resp.Header {
+		if !cassetteSkippedHeaders[name] {
+			recordedResp.Header[name] = fmt.Sprint(sanitizeValue(name, resp.Header.Get(name)))
+		}
+	}
+	recordedResp.Body, recordedResp.Text = sanitizeCassetteResponseBody(req.URL.Path, body)
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, cassetteInteraction{Request: recordedReq, Response: recordedResp})
+	c.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// replay answers with the first unused recording that matches the request
+func (c *Cassette) replay(req *http.Request, recordedReq cassetteRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.used[i] || !interaction.Request.matches(recordedReq) {
+			continue
+		}
+		c.used[i] = true
+		return interaction.Response.httpResponse(req), nil
+	}
+
+	description := recordedReq.Method + " " + recordedReq.Path
+	if len(recordedReq.Body) > 0 {
+		description += " " + string(recordedReq.Body)
+	}
+	c.unmatched = append(c.unmatched, description)
+	return nil, NewError(ErrCodeCassetteMismatch, fmt.Sprintf("No recorded interaction in %s matches %s", c.path, description))
+}
+
+// Unmatched returns the requests that had no recording, in replay mode
+func (c *Cassette) Unmatched() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.unmatched...)
+}
+
+// Close writes the cassette in record mode
+// In replay mode it returns an error if any request had no recording
+func (c *Cassette) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mode == CassetteReplay {
+		if len(c.unmatched) > 0 {
+			return fmt.Errorf("cassette %s: %d request(s) had no recording: %s",
+				c.path, len(c.unmatched), strings.Join(c.unmatched, "; "))
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cassetteFile{Version: cassetteVersion, Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// matches compares method, path and body
+// The body HMAC is compared when both requests have one, the sanitized body otherwise
+func (r cassetteRequest) matches(other cassetteRequest) bool {
+	if r.Method != other.Method || r.Path != other.Path {
+		return false
+	}
+	if r.BodyHMAC != "" && other.BodyHMAC != "" {
+		return hmac.Equal([]byte(r.BodyHMAC), []byte(other.BodyHMAC))
+	}
+	return bytes.Equal(canonicalJSON(r.Body), canonicalJSON(other.Body))
+}
+
+// httpResponse rebuilds the recorded response
+func (r cassetteResponse) httpResponse(req *http.Request) *http.Response {
+	body := []byte(r.Text)
+	if len(r.Body) > 0 {
+		body = r.Body
+	}
+	header := make(http.Header, len(r.Header))
+	for name, value := range r.Header {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// sanitizeCassetteRequestBody drops the nonce and sanitizes a JSON request body
+func sanitizeCassetteRequestBody(path string, raw []byte) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+	var body interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil
+	}
+	if fields, ok := body.(map[string]interface{}); ok {
+		delete(fields, "nonce")
+	}
+
+	body = sanitizeCassetteValue("", body)
+	// Identity attributes sent for KYC matching are personal data
+	if strings.HasPrefix(path, "/kyc") {
+		body = redactJSONValues(body)
+	}
+	encoded, _ := json.Marshal(body)
+	return encoded
+}
+
+// bodyHMAC returns the HMAC-SHA256 of a request body in canonical JSON, without the nonce
+// Returns "" when the cassette has no match key, so no digest of the body is recorded
+func (c *Cassette) bodyHMAC(raw []byte) string {
+	if len(c.matchKey) == 0 || len(raw) == 0 {
+		return ""
+	}
+	var body interface{}
+	if err := json.Unmarshal(raw, &body); err == nil {
+		if fields, ok := body.(map[string]interface{}); ok {
+			delete(fields, "nonce")
+		}
+		raw, _ = json.Marshal(body)
+	}
+	mac := hmac.New(sha256.New, c.matchKey)
+	mac.Write(raw)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// sanitizeCassetteResponseBody sanitizes a response body, returning it as JSON or as text
+func sanitizeCassetteResponseBody(path string, raw []byte) (json.RawMessage, string) {
+	if len(raw) == 0 {
+		return nil, ""
+	}
+	var body interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, string(raw)
+	}
+
+	body = sanitizeCassetteValue("", body)
+	// Identity data must never be stored in clear text
+	if fields, ok := body.(map[string]interface{}); ok && strings.Contains(path, "kyc-fill-in") {
+		if identity, ok := fields["identity"]; ok {
+			fields["identity"] = redactJSONValues(identity)
+		}
+	}
+	encoded, _ := json.Marshal(body)
+	return encoded, ""
+}
+
+// sanitizeCassetteValue applies sanitizeValue to every string in a decoded JSON value
+func sanitizeCassetteValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		sanitized := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			sanitized[k] = sanitizeCassetteValue(k, item)
+		}
+		return sanitized
+	case []interface{}:
+		sanitized := make([]interface{}, len(v))
+		for idx, item := range v {
+			sanitized[idx] = sanitizeCassetteValue(key, item)
+		}
+		return sanitized
+	case string:
+		if cassettePlainFields[key] {
+			return v
+		}
+		return sanitizeValue(key, v)
+	default:
+		return v
+	}
+}
+
+// canonicalJSON re-encodes JSON with sorted keys so equal bodies compare equal
+func canonicalJSON(raw json.RawMessage) []byte {
+	if len(raw) == 0 {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return raw
+	}
+	encoded, _ := json.Marshal(value)
+	return encoded
+}
@@ -0,0 +1,342 @@
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// CircuitState represents the state of a circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through and tracks failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests until the cooldown has passed
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through
+	CircuitHalfOpen
+)
+
+// String returns the string representation of a CircuitState
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerMetrics receives circuit breaker events, e.g. to export them as metrics
+type CircuitBreakerMetrics interface {
+	// OnStateChange is called when an operation's circuit changes state
+	OnStateChange(operation string, from, to CircuitState)
+	// OnResult is called for every request outcome recorded by the breaker
+	OnResult(operation string, success bool)
+	// OnRejected is called when a request is rejected because the circuit is open
+	OnRejected(operation string)
+}
+
+// CircuitBreakerConfig configures the per-operation circuit breakers
+type CircuitBreakerConfig struct {
+	FailureRateThreshold float64       // Failure rate (0-1) that opens the circuit (default: 0.5)
+	MinimumRequests      int           // Requests needed in the window before the rate is evaluated (default: 10)
+	Window               time.Duration // Period over which failures are counted (default: 60s)
+	Cooldown             time.Duration // Time the circuit stays open before probing (default: 30s)
+	HalfOpenMaxRequests  int           // Concurrent probe requests allowed when half-open (default: 1)
+
+	Metrics CircuitBreakerMetrics // Optional metrics hooks
+}
+
+// withDefaults fills in unset values
+func (cfg CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	if cfg.FailureRateThreshold <= 0 || cfg.FailureRateThreshold > 1 {
+		cfg.FailureRateThreshold = 0.5
+	}
+	if cfg.MinimumRequests <= 0 {
+		cfg.MinimumRequests = 10
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 60 * time.Second
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = 30 * time.Second
+	}
+	if cfg.HalfOpenMaxRequests <= 0 {
+		cfg.HalfOpenMaxRequests = 1
+	}
+	return cfg
+}
+
+// ErrCircuitOpen is returned when a call is rejected by an open circuit breaker
+// Use errors.Is(err, glide.ErrCircuitOpen) or IsCode(ErrCodeCircuitOpen) to detect it
+var ErrCircuitOpen = NewError(ErrCodeCircuitOpen, "Circuit breaker is open")
+
+// circuitBreaker tracks the health of a single operation
+type circuitBreaker struct {
+	operation string
+	config    CircuitBreakerConfig
+	logger    Logger
+
+	mu          sync.Mutex
+	state       CircuitState
+	openedAt    time.Time
+	windowStart time.Time
+	requests    int
+	failures    int
+	probes      int // In-flight half-open requests
+	generation  int // Incremented each time the circuit goes half-open
+}
+
+// circuitTicket identifies how a request was admitted by allow
+// record and release only let half-open probes of the current generation change the state
+type circuitTicket struct {
+	probe      bool
+	generation int
+}
+
+// circuitEvents holds logger and metrics notifications collected while b.mu is held
+// They are delivered after unlocking, so hooks may call back into the client and a slow
+// hook does not block other requests of the operation
+type circuitEvents []func()
+
+// deliver runs the collected notifications
+func (e circuitEvents) deliver() {
+	for _, notify := range e {
+		notify()
+	}
+}
+
+// allow reports whether a request may proceed and returns its ticket
+func (b *circuitBreaker) allow() (circuitTicket, bool) {
+	var events circuitEvents
+	defer func() { events.deliver() }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Now().Sub(b.openedAt) < b.config.Cooldown {
+			b.rejected(&events)
+			return circuitTicket{}, false
+		}
+		b.setState(CircuitHalfOpen, &events)
+		fallthrough
+	case CircuitHalfOpen:
+		if b.probes >= b.config.HalfOpenMaxRequests {
+			b.rejected(&events)
+			return circuitTicket{}, false
+		}
+		b.probes++
+		return circuitTicket{probe: true, generation: b.generation}, true
+	}
+	return circuitTicket{}, true
+}
+
+// isProbe reports whether ticket holds a probe slot of the current half-open period
+// Must be called with b.mu held
+func (b *circuitBreaker) isProbe(ticket circuitTicket) bool {
+	return ticket.probe && b.state == CircuitHalfOpen && ticket.generation == b.generation
+}
+
+// rejected queues the metrics notification of a rejected request
+func (b *circuitBreaker) rejected(events *circuitEvents) {
+	if metrics := b.config.Metrics; metrics != nil {
+		operation := b.operation
+		*events = append(*events, func() { metrics.OnRejected(operation) })
+	}
+}
+
+// record reports the outcome of an allowed request
+// Outcomes of requests admitted while closed are ignored once the circuit has left the closed state
+func (b *circuitBreaker) record(ticket circuitTicket, success bool) {
+	var events circuitEvents
+	defer func() { events.deliver() }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if metrics := b.config.Metrics; metrics != nil {
+		operation := b.operation
+		events = append(events, func() { metrics.OnResult(operation, success) })
+	}
+
+	switch {
+	case b.isProbe(ticket):
+		b.probes--
+		if success {
+			b.setState(CircuitClosed, &events)
+		} else {
+			b.setState(CircuitOpen, &events)
+		}
+
+	case !ticket.probe && b.state == CircuitClosed:
+		now := time.Now()
+		if now.Sub(b.windowStart) > b.config.Window {
+			b.windowStart = now
+			b.requests = 0
+			b.failures = 0
+		}
+		b.requests++
+		if !success {
+			b.failures++
+		}
+		if b.requests >= b.config.MinimumRequests &&
+			float64(b.failures)/float64(b.requests) >= b.config.FailureRateThreshold {
+			b.setState(CircuitOpen, &events)
+		}
+	}
+}
+
+// release returns a probe slot without recording an outcome
+func (b *circuitBreaker) release(ticket circuitTicket) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.isProbe(ticket) {
+		b.probes--
+	}
+}
+
+// currentState returns the breaker state
+func (b *circuitBreaker) currentState() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// retryAfter returns the remaining cooldown of an open circuit
+func (b *circuitBreaker) retryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != CircuitOpen {
+		return 0
+	}
+	if remaining := b.config.Cooldown - time.Now().Sub(b.openedAt); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// setState transitions the breaker and queues the logger and metrics notifications
+// Must be called with b.mu held
+func (b *circuitBreaker) setState(to CircuitState, events *circuitEvents) {
+	from := b.state
+	if from == to {
+		return
+	}
+
+	b.state = to
+	now := time.Now()
+	switch to {
+	case CircuitOpen:
+		b.openedAt = now
+		b.probes = 0
+	case CircuitHalfOpen:
+		b.generation++
+	case CircuitClosed:
+		b.windowStart = now
+		b.requests = 0
+		b.failures = 0
+		b.probes = 0
+	}
+
+	operation, logger, metrics, cooldown := b.operation, b.logger, b.config.Metrics, b.config.Cooldown
+	*events = append(*events, func() {
+		fields := []Field{
+			{"operation", operation},
+			{"from", from.String()},
+			{"to", to.String()},
+		}
+		if to == CircuitOpen {
+			logger.Warn("Circuit breaker opened", append(fields, Field{"cooldown", cooldown.String()})...)
+		} else {
+			logger.Info("Circuit breaker state changed", fields...)
+		}
+
+		if metrics != nil {
+			metrics.OnStateChange(operation, from, to)
+		}
+	})
+}
+
+// circuitBreakers holds one breaker per operation
+type circuitBreakers struct {
+	config   CircuitBreakerConfig
+	logger   Logger
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+// newCircuitBreakers creates the breaker set for a client
+func newCircuitBreakers(cfg CircuitBreakerConfig, logger Logger) *circuitBreakers {
+	return &circuitBreakers{
+		config:   cfg.withDefaults(),
+		logger:   logger,
+		breakers: make(map[string]*circuitBreaker),
+	}
+}
+
+// get returns the breaker for an operation, creating it on first use
+func (g *circuitBreakers) get(operation string) *circuitBreaker {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.breakers[operation]
+	if !ok {
+		b = &circuitBreaker{
+			operation: operation,
+			config:    g.config,
+			logger:    g.logger,
+
+			windowStart: time.Now(),
+		}
+		g.breakers[operation] = b
+	}
+	return b
+}
+
+// newCircuitOpenError creates the error returned for rejected calls
+func newCircuitOpenError(b *circuitBreaker) *Error {
+	err := NewError(ErrCodeCircuitOpen, ErrCircuitOpen.Message)
+	err.Details = map[string]interface{}{
+		"operation":   b.operation,
+		"retry_after": b.retryAfter().Seconds(),
+	}
+	err.cause = ErrCircuitOpen // Matched by errors.Is through Unwrap
+	return err
+}
+
+// isCircuitFailure reports whether an error indicates a degraded backend
+// Client errors (4xx) mean the backend is healthy and count as successes
+func isCircuitFailure(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	var glideErr *Error
+	if !errors.As(err, &glideErr) {
+		return true
+	}
+	if glideErr.Status >= 500 {
+		return true
+	}
+	// Transport failures have no status
+	return glideErr.Status == 0 && glideErr.Code == ErrCodeServiceUnavailable && !errors.Is(ctx.Err(), context.Canceled)
+}
+
+// CircuitBreakerState returns the circuit state for an operation path, e.g. "/sim-swap/check"
+// Returns CircuitClosed when circuit breaking is disabled
+func (c *Client) CircuitBreakerState(operation string) CircuitState {
+	if c.breakers == nil {
+		return CircuitClosed
+	}
+	return c.breakers.get(operation).currentState()
+}
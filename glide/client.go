@@ -2,11 +2,10 @@ package glide
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"os"
 	"time"
-
-	"golang.org/x/time/rate"
 )
 
 // Client is the main Glide SDK client
@@ -17,10 +16,17 @@ type Client struct {
 	NumberVerify NumberVerifyService
 	KYC          KYCService
 
+	LocationVerification LocationVerificationService
+	DeviceStatus         DeviceStatusService
+
 	// Internal
 	config      *Config
 	httpClient  *http.Client
-	rateLimiter *rate.Limiter
+	rateLimiter RateLimiter
+	breakers    *circuitBreakers
+	quota       *quotaTracker
+	endpoints   *endpointPool
+	diagnostics *diagnosticsCache
 	logger      Logger
 }
 
@@ -32,11 +38,49 @@ type Config struct {
 	RetryCount int
 	RetryDelay time.Duration
 
+	// Credentials supplies the API key per request instead of APIKey, for rotation without a restart
+	Credentials CredentialProvider
+
+	// Regional endpoints (optional); when set, they replace BaseURL
+	Endpoints        []Endpoint
+	EndpointPolicy   EndpointPolicy // Order in which endpoints are tried (default: EndpointPolicyFailover)
+	EndpointCooldown time.Duration  // How long a failed endpoint is skipped (default: 30s)
+	DataResidency    string         // Only use endpoints serving this country, e.g. "DE"
+	HealthCheckPath  string         // Read-only GET endpoint probed by Ping and Diagnose (default: "/health")
+
+	// AutoIdempotencyKeys generates an Idempotency-Key per call (default: true)
+	// When disabled, non-idempotent operations are only retried if the caller supplies a key
+	AutoIdempotencyKeys bool
+
 	// Optional rate limiting
 	RateLimitEnabled bool
 	RateLimitRate    int
 	RateLimitPeriod  time.Duration
 
+	OperationRateLimits map[string]RateLimit // Per-operation limits keyed by endpoint path or prefix
+	RateLimiter         RateLimiter          // Custom limiter, e.g. NewDistributedRateLimiter (optional)
+
+	// Server quota awareness from rate limit response headers
+	QuotaPacing           bool    // Slow down when the server quota runs low (default: true)
+	QuotaWarningThreshold float64 // Fraction of the quota below which a warning is logged (default: 0.1)
+
+	// Optional per-operation circuit breaker (nil disables it)
+	CircuitBreaker *CircuitBreakerConfig
+
+	// Phone number normalization (optional)
+	NormalizePhoneNumbers bool   // Parse formatted input and convert it to E.164
+	DefaultRegion         string // Region used for national numbers, e.g. "US"
+
+	// StrictPLMNValidation rejects PLMNs missing from the MCC/MNC registry
+	StrictPLMNValidation bool
+
+	// PLMNRegistry replaces the embedded MCC/MNC dataset for this client (optional)
+	PLMNRegistry *PLMNRegistry
+
+	// KYC input handling
+	KYCNormalize bool // Normalize KYC match input before sending (default: false)
+	KYCHashing   bool // Send SHA-256 hashed values for fields that support it, implies KYCNormalize
+
 	// HTTP client (optional)
 	HTTPClient *http.Client
 
@@ -45,18 +89,27 @@ type Config struct {
 	LogLevel  LogLevel  // Log level (default: LogLevelSilent)
 	LogFormat LogFormat // Log output format (default: LogFormatPretty)
 	Logger    Logger    // Custom logger implementation (optional)
+	LogOutput io.Writer // Destination of the default logger (default: os.Stdout)
 }
 
 // New creates a new Glide client with the given options
+// The configuration is not validated; use NewWithError to reject invalid settings
 func New(opts ...Option) *Client {
-	cfg := &Config{
-		BaseURL:    "https://api.glideidentity.app",
-		Timeout:    30 * time.Second,
-		RetryCount: 3,
-		RetryDelay: time.Second,
-		LogLevel:   LogLevelSilent,  // Default to no logging
-		LogFormat:  LogFormatPretty, // Default to pretty format
+	return newClient(newConfig(opts))
+}
+
+// NewWithError creates a new Glide client, returning a VALIDATION_ERROR if the configuration is invalid
+func NewWithError(opts ...Option) (*Client, error) {
+	cfg := newConfig(opts)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
+	return newClient(cfg), nil
+}
+
+// newConfig applies the logging environment variables and the options to the defaults
+func newConfig(opts []Option) *Config {
+	cfg := defaultConfig()
 
 	// Check environment variables for debug mode
 	if envDebug := os.Getenv("GLIDE_DEBUG"); envDebug != "" {
@@ -83,7 +136,11 @@ func New(opts ...Option) *Client {
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	return cfg
+}
 
+// newClient creates a client from a complete configuration
+func newClient(cfg *Config) *Client {
 	// Create HTTP client if not provided
 	if cfg.HTTPClient == nil {
 		cfg.HTTPClient = &http.Client{
@@ -102,7 +159,7 @@ func New(opts ...Option) *Client {
 		client.logger = cfg.Logger
 	} else if cfg.Debug || cfg.LogLevel > LogLevelSilent {
 		// Use default logger with specified level and format
-		client.logger = NewDefaultLoggerWithFormat(cfg.LogLevel, cfg.LogFormat)
+		client.logger = NewDefaultLoggerWithOutput(cfg.LogLevel, cfg.LogFormat, cfg.LogOutput)
 	} else {
 		// Use noop logger when logging is disabled
 		client.logger = NewNoopLogger()
@@ -118,12 +175,32 @@ func New(opts ...Option) *Client {
 	}
 
 	// Initialize rate limiter if configured
+	client.quota = newQuotaTracker(cfg.QuotaWarningThreshold, client.logger)
+	client.initRateLimiter()
 	if cfg.RateLimitEnabled {
-		limit := rate.Every(cfg.RateLimitPeriod / time.Duration(cfg.RateLimitRate))
-		client.rateLimiter = rate.NewLimiter(limit, cfg.RateLimitRate)
 		client.logger.Debug("Rate limiting enabled",
 			Field{"rate", cfg.RateLimitRate},
 			Field{"period", cfg.RateLimitPeriod.String()},
+			Field{"operations", len(cfg.OperationRateLimits)},
+		)
+	}
+
+	client.endpoints = newEndpointPool(cfg, client.logger)
+	client.diagnostics = &diagnosticsCache{}
+	if len(cfg.Endpoints) > 0 {
+		client.logger.Debug("Regional endpoints enabled",
+			Field{"endpoints", len(cfg.Endpoints)},
+			Field{"policy", cfg.EndpointPolicy.String()},
+			Field{"dataResidency", cfg.DataResidency},
+		)
+	}
+
+	// Initialize circuit breakers if configured
+	if cfg.CircuitBreaker != nil {
+		client.breakers = newCircuitBreakers(*cfg.CircuitBreaker, client.logger)
+		client.logger.Debug("Circuit breaker enabled",
+			Field{"failureRateThreshold", client.breakers.config.FailureRateThreshold},
+			Field{"cooldown", client.breakers.config.Cooldown.String()},
 		)
 	}
 
@@ -132,6 +209,47 @@ func New(opts ...Option) *Client {
 	client.SimSwap = newSimSwapService(client)
 	client.NumberVerify = newNumberVerifyService(client)
 	client.KYC = newKYCService(client)
+	client.LocationVerification = newLocationVerificationService(client)
+	client.DeviceStatus = newDeviceStatusService(client)
+
+	return client
+}
+
+// Services holds service implementations to use instead of the HTTP-backed defaults
+// Nil fields keep the default implementation
+type Services struct {
+	MagicAuth    MagicAuthService
+	SimSwap      SimSwapService
+	NumberVerify NumberVerifyService
+	KYC          KYCService
+
+	LocationVerification LocationVerificationService
+	DeviceStatus         DeviceStatusService
+}
+
+// NewClientWithServices creates a client whose services are replaced by the given implementations
+// This lets code that depends on *Client be tested without HTTP, e.g. with the glidemock package
+func NewClientWithServices(services Services, opts ...Option) *Client {
+	client := New(opts...)
+
+	if services.MagicAuth != nil {
+		client.MagicAuth = services.MagicAuth
+	}
+	if services.SimSwap != nil {
+		client.SimSwap = services.SimSwap
+	}
+	if services.NumberVerify != nil {
+		client.NumberVerify = services.NumberVerify
+	}
+	if services.KYC != nil {
+		client.KYC = services.KYC
+	}
+	if services.LocationVerification != nil {
+		client.LocationVerification = services.LocationVerification
+	}
+	if services.DeviceStatus != nil {
+		client.DeviceStatus = services.DeviceStatus
+	}
 
 	return client
 }
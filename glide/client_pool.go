@@ -0,0 +1,285 @@
+package glide
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TenantCredentials are the settings of one tenant
+type TenantCredentials struct {
+	APIKey string
+
+	// Options are applied after the pool options, e.g. WithRateLimit for the tenant's quota
+	Options []Option
+}
+
+// TenantCredentialProvider looks up the credentials of a tenant, e.g. in a database or secret store
+type TenantCredentialProvider interface {
+	Credentials(ctx context.Context, tenantID string) (TenantCredentials, error)
+}
+
+// TenantCredentialProviderFunc adapts a function to TenantCredentialProvider
+type TenantCredentialProviderFunc func(ctx context.Context, tenantID string) (TenantCredentials, error)
+
+// Credentials calls f
+func (f TenantCredentialProviderFunc) Credentials(ctx context.Context, tenantID string) (TenantCredentials, error) {
+	return f(ctx, tenantID)
+}
+
+// ClientPoolConfig configures a ClientPool
+type ClientPoolConfig struct {
+	Provider TenantCredentialProvider // Required
+
+	// Options are applied to every tenant client, before the tenant's own options
+	// Do not share a RateLimiter here; give each tenant its own in TenantCredentials.Options
+	Options []Option
+
+	// Transport is shared by all tenant clients (default: a clone of http.DefaultTransport)
+	Transport http.RoundTripper
+
+	// RefreshInterval re-reads credentials this long after they were fetched, so rotated
+	// keys are picked up without calling Rotate (default: 0, never)
+	RefreshInterval time.Duration
+}
+
+// ClientPool lazily builds and caches one Client per tenant
+//
+// Tenant clients share the HTTP transport and its connection pool, but each has its own
+// rate limiter, quota tracker and circuit breakers, so one tenant exhausting its quota or
+// tripping a breaker does not affect the others.
+type ClientPool struct {
+	config    ClientPoolConfig
+	transport http.RoundTripper
+
+	mu      sync.Mutex
+	tenants map[string]*tenantEntry
+}
+
+// tenantEntry is the cached client of a tenant
+// Its mutex serializes credential lookups so each tenant's client is built once
+type tenantEntry struct {
+	mu        sync.Mutex
+	client    *Client
+	apiKey    string
+	fetchedAt time.Time
+	pruned    bool // Dropped from the pool after a failed first lookup
+}
+
+// NewClientPool creates a pool of tenant clients
+func NewClientPool(cfg ClientPoolConfig) *ClientPool {
+	transport := cfg.Transport
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	return &ClientPool{
+		config:    cfg,
+		transport: transport,
+		tenants:   make(map[string]*tenantEntry),
+	}
+}
+
+// Client returns the client of a tenant, building it from the provider on first use
+// If refreshing the credentials fails, the cached client keeps being used
+func (p *ClientPool) Client(ctx context.Context, tenantID string) (*Client, error) {
+	entry, err := p.lockEntry(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+
+	if entry.client != nil && !p.stale(entry) {
+		return entry.client, nil
+	}
+	if err := p.load(ctx, tenantID, entry, false); err != nil && entry.client == nil {
+		p.prune(tenantID, entry)
+		return nil, err
+	}
+	return entry.client, nil
+}
+
+// Rotate re-reads a tenant's credentials and replaces its client, e.g. after a key rotation
+// Calls already holding the old client complete with it. The rate limiter, quota and circuit
+// breakers carry over when their settings are unchanged.
+func (p *ClientPool) Rotate(ctx context.Context, tenantID string) (*Client, error) {
+	entry, err := p.lockEntry(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+
+	if err := p.load(ctx, tenantID, entry, true); err != nil {
+		if entry.client == nil {
+			p.prune(tenantID, entry)
+		}
+		return nil, err
+	}
+	return entry.client, nil
+}
+
+// Remove drops a tenant's client, e.g. when a merchant is offboarded
+func (p *ClientPool) Remove(tenantID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.tenants, tenantID)
+}
+
+// Tenants returns the IDs of the tenants with a cached client, sorted
+func (p *ClientPool) Tenants() []string {
+	// Snapshot the entries so p.mu is never held while waiting for a credential lookup
+	p.mu.Lock()
+	entries := make(map[string]*tenantEntry, len(p.tenants))
+	for id, entry := range p.tenants {
+		entries[id] = entry
+	}
+	p.mu.Unlock()
+
+	ids := make([]string, 0, len(entries))
+	for id, entry := range entries {
+		entry.mu.Lock()
+		if entry.client != nil {
+			ids = append(ids, id)
+		}
+		entry.mu.Unlock()
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// CloseIdleConnections closes the idle connections of the shared transport
+func (p *ClientPool) CloseIdleConnections() {
+	if closer, ok := p.transport.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// entry returns the cache entry of a tenant, creating an empty one if needed
+func (p *ClientPool) entry(tenantID string) (*tenantEntry, error) {
+	if tenantID == "" {
+		v := &ValidationError{}
+		v.AddMissing("tenant_id", "Tenant ID is required")
+		return nil, v.Err()
+	}
+	if p.config.Provider == nil {
+		return nil, NewError(ErrCodeInternalServerError, "Client pool has no credential provider")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.tenants[tenantID]
+	if !ok {
+		entry = &tenantEntry{}
+		p.tenants[tenantID] = entry
+	}
+	return entry, nil
+}
+
+// lockEntry returns the locked cache entry of a tenant
+// An entry pruned while the caller waited for its lock is replaced by a fresh one
+func (p *ClientPool) lockEntry(tenantID string) (*tenantEntry, error) {
+	for {
+		entry, err := p.entry(tenantID)
+		if err != nil {
+			return nil, err
+		}
+		entry.mu.Lock()
+		if !entry.pruned {
+			return entry, nil
+		}
+		entry.mu.Unlock()
+	}
+}
+
+// prune drops an entry that never got a client, so failed lookups of unknown tenants do not pile up
+// The caller holds entry.mu
+func (p *ClientPool) prune(tenantID string, entry *tenantEntry) {
+	entry.pruned = true
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.tenants[tenantID] == entry {
+		delete(p.tenants, tenantID)
+	}
+}
+
+// stale reports whether the entry's credentials are due for a refresh
+func (p *ClientPool) stale(entry *tenantEntry) bool {
+	return p.config.RefreshInterval > 0 && time.Since(entry.fetchedAt) >= p.config.RefreshInterval
+}
+
+// load fetches the credentials and rebuilds the client when forced or when the key changed
+// The caller holds entry.mu
+func (p *ClientPool) load(ctx context.Context, tenantID string, entry *tenantEntry, force bool) error {
+	creds, err := p.config.Provider.Credentials(ctx, tenantID)
+	if err != nil {
+		if entry.client != nil {
+			entry.client.logger.Warn("Failed to refresh tenant credentials, keeping the current client",
+				Field{"tenant", tenantID},
+				Field{"error", err.Error()},
+			)
+			// Wait a full interval before asking the provider again
+			entry.fetchedAt = time.Now()
+		}
+		return err
+	}
+
+	if entry.client != nil && !force && creds.APIKey == entry.apiKey {
+		entry.fetchedAt = time.Now()
+		return nil
+	}
+
+	client, err := p.build(creds)
+	if err != nil {
+		return err
+	}
+	if entry.client != nil {
+		client.inheritState(entry.client)
+		client.logger.Info("Tenant client rotated", Field{"tenant", tenantID})
+	}
+	entry.client = client
+	entry.apiKey = creds.APIKey
+	entry.fetchedAt = time.Now()
+	return nil
+}
+
+// build creates a validated tenant client on the shared transport
+func (p *ClientPool) build(creds TenantCredentials) (*Client, error) {
+	opts := make([]Option, 0, len(p.config.Options)+len(creds.Options)+1)
+	opts = append(opts, p.config.Options...)
+	opts = append(opts, WithAPIKey(creds.APIKey))
+	opts = append(opts, creds.Options...)
+
+	cfg := newConfig(opts)
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Transport: p.transport, Timeout: cfg.Timeout}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return newClient(cfg), nil
+}
+
+// inheritState keeps the pacing and circuit state of the client this one replaces
+// so that a key rotation neither resets the tenant's quota nor forgets an open circuit
+func (c *Client) inheritState(old *Client) {
+	if sameRateLimits(c.config, old.config) {
+		c.rateLimiter = old.rateLimiter
+		c.quota = old.quota
+	}
+	if c.breakers != nil && old.breakers != nil && reflect.DeepEqual(c.config.CircuitBreaker, old.config.CircuitBreaker) {
+		c.breakers = old.breakers
+	}
+}
+
+// sameRateLimits reports whether two configurations limit requests the same way
+func sameRateLimits(a, b *Config) bool {
+	return a.RateLimitEnabled == b.RateLimitEnabled &&
+		a.RateLimitRate == b.RateLimitRate &&
+		a.RateLimitPeriod == b.RateLimitPeriod &&
+		reflect.DeepEqual(a.RateLimiter, b.RateLimiter) &&
+		a.QuotaPacing == b.QuotaPacing &&
+		a.QuotaWarningThreshold == b.QuotaWarningThreshold &&
+		reflect.DeepEqual(a.OperationRateLimits, b.OperationRateLimits)
+}
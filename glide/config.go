@@ -0,0 +1,444 @@
+package glide
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/phonenumber"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultBaseURL is the production API endpoint
+const DefaultBaseURL = "https://api.glideidentity.app"
+
+// defaultConfig returns the configuration before environment variables and options are applied
+func defaultConfig() *Config {
+	return &Config{
+		BaseURL:    DefaultBaseURL,
+		Timeout:    30 * time.Second,
+		RetryCount: 3,
+		RetryDelay: time.Second,
+		LogLevel:   LogLevelSilent,  // Default to no logging
+		LogFormat:  LogFormatPretty, // Default to pretty format
+
+		AutoIdempotencyKeys:   true,
+		QuotaPacing:           true,
+		QuotaWarningThreshold: DefaultQuotaWarningThreshold,
+		EndpointCooldown:      DefaultEndpointCooldown,
+		HealthCheckPath:       DefaultHealthCheckPath,
+	}
+}
+
+// configSetting is a setting that can come from a config file or an environment variable
+type configSetting struct {
+	key   string // File key, dotted for nested keys, e.g. "rate_limit.rate"
+	apply func(cfg *Config, value string) error
+}
+
+// env returns the environment variable for the setting, e.g. GLIDE_RATE_LIMIT_RATE
+func (s configSetting) env() string {
+	return "GLIDE_" + strings.ToUpper(strings.ReplaceAll(s.key, ".", "_"))
+}
+
+// configSettings lists the settings read by LoadConfig
+// Within a source they are applied in this order, so "enabled" switches come after the values that imply them
+var configSettings = []configSetting{
+	{"api_key", func(cfg *Config, v string) error { cfg.APIKey = v; cfg.Credentials = nil; return nil }},
+	{"api_key_file", func(cfg *Config, v string) error {
+		provider, err := NewFileCredentialProvider(v, 0)
+		if err != nil {
+			return err
+		}
+		cfg.Credentials = provider
+		return nil
+	}},
+	{"base_url", func(cfg *Config, v string) error { cfg.BaseURL = v; return nil }},
+	{"endpoint_policy", func(cfg *Config, v string) error {
+		policy, err := ParseEndpointPolicy(v)
+		if err != nil {
+			return err
+		}
+		cfg.EndpointPolicy = policy
+		return nil
+	}},
+	{"endpoint_cooldown", durationSetting(func(cfg *Config, d time.Duration) { cfg.EndpointCooldown = d })},
+	{"data_residency", func(cfg *Config, v string) error { cfg.DataResidency = v; return nil }},
+	{"health_check_path", func(cfg *Config, v string) error { cfg.HealthCheckPath = v; return nil }},
+	{"timeout", durationSetting(func(cfg *Config, d time.Duration) { cfg.Timeout = d })},
+	{"retry_count", intSetting(func(cfg *Config, n int) { cfg.RetryCount = n })},
+	{"retry_delay", durationSetting(func(cfg *Config, d time.Duration) { cfg.RetryDelay = d })},
+	{"auto_idempotency_keys", boolSetting(func(cfg *Config, b bool) { cfg.AutoIdempotencyKeys = b })},
+
+	{"rate_limit.rate", intSetting(func(cfg *Config, n int) { cfg.RateLimitEnabled = true; cfg.RateLimitRate = n })},
+	{"rate_limit.period", durationSetting(func(cfg *Config, d time.Duration) { cfg.RateLimitEnabled = true; cfg.RateLimitPeriod = d })},
+	{"rate_limit.enabled", boolSetting(func(cfg *Config, b bool) { cfg.RateLimitEnabled = b })},
+
+	{"quota_pacing", boolSetting(func(cfg *Config, b bool) { cfg.QuotaPacing = b })},
+	{"quota_warning_threshold", floatSetting(func(cfg *Config, f float64) { cfg.QuotaWarningThreshold = f })},
+
+	{"circuit_breaker.failure_rate_threshold", floatSetting(func(cfg *Config, f float64) { circuitBreakerConfig(cfg).FailureRateThreshold = f })},
+	{"circuit_breaker.minimum_requests", intSetting(func(cfg *Config, n int) { circuitBreakerConfig(cfg).MinimumRequests = n })},
+	{"circuit_breaker.window", durationSetting(func(cfg *Config, d time.Duration) { circuitBreakerConfig(cfg).Window = d })},
+	{"circuit_breaker.cooldown", durationSetting(func(cfg *Config, d time.Duration) { circuitBreakerConfig(cfg).Cooldown = d })},
+	{"circuit_breaker.half_open_max_requests", intSetting(func(cfg *Config, n int) { circuitBreakerConfig(cfg).HalfOpenMaxRequests = n })},
+	{"circuit_breaker.enabled", boolSetting(func(cfg *Config, b bool) {
+		if !b {
+			cfg.CircuitBreaker = nil
+		} else {
+			circuitBreakerConfig(cfg)
+		}
+	})},
+
+	{"normalize_phone_numbers", boolSetting(func(cfg *Config, b bool) { cfg.NormalizePhoneNumbers = b })},
+	{"default_region", func(cfg *Config, v string) error { cfg.DefaultRegion = v; return nil }},
+	{"strict_plmn_validation", boolSetting(func(cfg *Config, b bool) { cfg.StrictPLMNValidation = b })},
+	{"kyc_normalize", boolSetting(func(cfg *Config, b bool) { cfg.KYCNormalize = b })},
+	{"kyc_hashing", boolSetting(func(cfg *Config, b bool) { cfg.KYCHashing = b })},
+
+	{"debug", boolSetting(func(cfg *Config, b bool) {
+		cfg.Debug = b
+		if b {
+			cfg.LogLevel = LogLevelDebug
+		}
+	})},
+	{"log_level", func(cfg *Config, v string) error {
+		if !validLogLevels[strings.ToLower(v)] {
+			return fmt.Errorf("unknown log level %q (use debug, info, warn, error or silent)", v)
+		}
+		cfg.LogLevel = ParseLogLevel(v)
+		cfg.Debug = cfg.LogLevel > LogLevelSilent
+		return nil
+	}},
+	{"log_format", func(cfg *Config, v string) error {
+		if !validLogFormats[strings.ToLower(v)] {
+			return fmt.Errorf("unknown log format %q (use pretty, json or simple)", v)
+		}
+		cfg.LogFormat = ParseLogFormat(v)
+		return nil
+	}},
+}
+
+// configOperationsKey holds per-operation rate limits, which are only read from config files
+const configOperationsKey = "rate_limit.operations"
+
+// configEndpointsKey holds the list of regional endpoints, which is only read from config files
+const configEndpointsKey = "endpoints"
+
+var validLogLevels = map[string]bool{
+	"debug": true, "info": true, "warn": true, "warning": true, "error": true, "silent": true, "none": true, "off": true,
+}
+
+var validLogFormats = map[string]bool{"pretty": true, "json": true, "simple": true}
+
+// LoadConfig builds a Config from the defaults, a YAML or JSON config file and GLIDE_* environment variables
+//
+// Later sources take precedence: defaults, then the file, then the environment. The file is
+// read from path, or from $GLIDE_CONFIG when path is empty; with neither, only the environment
+// is used. File keys are snake_case and nest with sections, e.g. rate_limit.rate; the matching
+// environment variable is GLIDE_RATE_LIMIT_RATE. Durations use Go syntax such as "30s".
+//
+// Pass the result to NewWithError with WithConfig, followed by any options that should take
+// precedence over it. LoadConfig reports unknown keys and malformed values; NewWithError
+// validates the final configuration.
+func LoadConfig(path string) (*Config, error) {
+	cfg := defaultConfig()
+	v := &ValidationError{}
+
+	if path == "" {
+		path = os.Getenv("GLIDE_CONFIG")
+	}
+	if path != "" {
+		values, err := readConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+		known := map[string]bool{configOperationsKey: true, configEndpointsKey: true}
+		for _, s := range configSettings {
+			known[s.key] = true
+			if value, ok := values[s.key]; ok {
+				if err := s.apply(cfg, fmt.Sprint(value)); err != nil {
+					v.Add(s.key, RuleFormat, fmt.Sprintf("%s: %v", s.key, err))
+				}
+			}
+		}
+		if operations, ok := values[configOperationsKey]; ok {
+			applyOperationRateLimits(cfg, v, operations)
+		}
+		if endpoints, ok := values[configEndpointsKey]; ok {
+			applyEndpoints(cfg, v, endpoints)
+		}
+		for _, key := range sortedKeys(values) {
+			if !known[key] {
+				v.Add(key, RuleForbidden, fmt.Sprintf("unknown setting %s in %s", key, path))
+			}
+		}
+	}
+
+	for _, s := range configSettings {
+		if value, ok := os.LookupEnv(s.env()); ok && value != "" {
+			if err := s.apply(cfg, value); err != nil {
+				v.Add(s.env(), RuleFormat, fmt.Sprintf("%s: %v", s.env(), err))
+			}
+		}
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// readConfigFile decodes a YAML or JSON file, chosen by extension, into dotted keys
+// Sections are flattened except rate_limit.operations, which is kept as a map
+func readConfigFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var raw map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file %s (use .yaml, .yml or .json)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	values := make(map[string]interface{})
+	flattenConfig("", raw, values)
+	return values, nil
+}
+
+// flattenConfig adds the leaves of a decoded section to values under dotted keys
+func flattenConfig(prefix string, section map[string]interface{}, values map[string]interface{}) {
+	for key, value := range section {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && key != configOperationsKey {
+			flattenConfig(key, nested, values)
+			continue
+		}
+		if value != nil {
+			values[key] = value
+		}
+	}
+}
+
+// applyOperationRateLimits reads rate_limit.operations, a map of operation to rate and period
+func applyOperationRateLimits(cfg *Config, v *ValidationError, value interface{}) {
+	operations, ok := value.(map[string]interface{})
+	if !ok {
+		v.Add(configOperationsKey, RuleFormat, configOperationsKey+" must map operations to a rate and period")
+		return
+	}
+	for _, operation := range sortedKeys(operations) {
+		field := configOperationsKey + "." + operation
+		limit, ok := operations[operation].(map[string]interface{})
+		if !ok {
+			v.Add(field, RuleFormat, field+" must have a rate and period")
+			continue
+		}
+		rate, err := strconv.Atoi(fmt.Sprint(limit["rate"]))
+		if err != nil {
+			v.Add(field+".rate", RuleFormat, fmt.Sprintf("%s.rate: invalid integer %q", field, fmt.Sprint(limit["rate"])))
+			continue
+		}
+		period, err := time.ParseDuration(fmt.Sprint(limit["period"]))
+		if err != nil {
+			v.Add(field+".period", RuleFormat, fmt.Sprintf("%s.period: invalid duration %q", field, fmt.Sprint(limit["period"])))
+			continue
+		}
+		WithOperationRateLimit(operation, rate, period)(cfg)
+	}
+}
+
+// applyEndpoints reads endpoints, a list of entries with a url and optional region and countries
+func applyEndpoints(cfg *Config, v *ValidationError, value interface{}) {
+	entries, ok := value.([]interface{})
+	if !ok {
+		v.Add(configEndpointsKey, RuleFormat, configEndpointsKey+" must be a list of endpoints")
+		return
+	}
+	cfg.Endpoints = nil
+	for i, entry := range entries {
+		field := fmt.Sprintf("%s[%d]", configEndpointsKey, i)
+		settings, ok := entry.(map[string]interface{})
+		if !ok || settings["url"] == nil {
+			v.Add(field, RuleFormat, field+" must have a url")
+			continue
+		}
+		endpoint := Endpoint{URL: fmt.Sprint(settings["url"])}
+		if region, ok := settings["region"]; ok {
+			endpoint.Region = fmt.Sprint(region)
+		}
+		if countries, ok := settings["countries"]; ok {
+			list, ok := countries.([]interface{})
+			if !ok {
+				v.Add(field+".countries", RuleFormat, field+".countries must be a list of country codes")
+				continue
+			}
+			for _, country := range list {
+				endpoint.Countries = append(endpoint.Countries, fmt.Sprint(country))
+			}
+		}
+		cfg.Endpoints = append(cfg.Endpoints, endpoint)
+	}
+}
+
+// circuitBreakerConfig returns the circuit breaker settings, enabling the breaker with defaults if needed
+func circuitBreakerConfig(cfg *Config) *CircuitBreakerConfig {
+	if cfg.CircuitBreaker == nil {
+		cfg.CircuitBreaker = &CircuitBreakerConfig{}
+	}
+	return cfg.CircuitBreaker
+}
+
+func boolSetting(set func(*Config, bool)) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		set(cfg, b)
+		return nil
+	}
+}
+
+func intSetting(set func(*Config, int)) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		set(cfg, n)
+		return nil
+	}
+}
+
+func floatSetting(set func(*Config, float64)) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		set(cfg, f)
+		return nil
+	}
+}
+
+func durationSetting(set func(*Config, time.Duration)) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (use e.g. 500ms, 30s or 1m)", value)
+		}
+		set(cfg, d)
+		return nil
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate checks the configuration and returns a VALIDATION_ERROR listing every problem
+// Field names in the violations are the config file keys
+func (c *Config) Validate() error {
+	v := &ValidationError{}
+
+	if c.APIKey == "" && c.Credentials == nil {
+		v.Add("api_key", RuleRequired, "API key is required")
+	}
+	if u, err := url.Parse(c.BaseURL); len(c.Endpoints) == 0 && (err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "") {
+		v.Add("base_url", RuleURL, fmt.Sprintf("base URL %q must be an absolute http or https URL", c.BaseURL))
+	}
+	if c.Timeout < 0 {
+		v.Add("timeout", RuleRange, "timeout must not be negative")
+	}
+	if c.RetryCount < 0 {
+		v.Add("retry_count", RuleRange, "retry count must not be negative")
+	}
+	if c.RetryDelay < 0 {
+		v.Add("retry_delay", RuleRange, "retry delay must not be negative")
+	}
+	if c.HealthCheckPath != "" && !strings.HasPrefix(c.HealthCheckPath, "/") {
+		v.Add("health_check_path", RuleFormat, fmt.Sprintf("health check path %q must start with /", c.HealthCheckPath))
+	}
+	c.validateEndpoints(v)
+
+	// A custom limiter brings its own limits
+	if c.RateLimitEnabled && c.RateLimiter == nil {
+		global := c.RateLimitRate != 0 || c.RateLimitPeriod != 0 || len(c.OperationRateLimits) == 0
+		if global && c.RateLimitRate <= 0 {
+			v.Add("rate_limit.rate", RuleRange, "rate limit rate must be positive when rate limiting is enabled")
+		}
+		if global && c.RateLimitPeriod <= 0 {
+			v.Add("rate_limit.period", RuleRange, "rate limit period must be positive when rate limiting is enabled")
+		}
+		for _, operation := range sortedRateLimitKeys(c.OperationRateLimits) {
+			if !c.OperationRateLimits[operation].valid() {
+				v.Add(configOperationsKey+"."+operation, RuleRange,
+					fmt.Sprintf("rate limit for %s must have a positive rate and period", operation))
+			}
+		}
+	}
+
+	if c.QuotaWarningThreshold < 0 || c.QuotaWarningThreshold > 1 {
+		v.Add("quota_warning_threshold", RuleRange, "quota warning threshold must be between 0 and 1")
+	}
+
+	if cb := c.CircuitBreaker; cb != nil {
+		if cb.FailureRateThreshold < 0 || cb.FailureRateThreshold > 1 {
+			v.Add("circuit_breaker.failure_rate_threshold", RuleRange, "circuit breaker failure rate threshold must be between 0 and 1")
+		}
+		if cb.MinimumRequests < 0 || cb.HalfOpenMaxRequests < 0 {
+			v.Add("circuit_breaker", RuleRange, "circuit breaker request counts must not be negative")
+		}
+		if cb.Window < 0 || cb.Cooldown < 0 {
+			v.Add("circuit_breaker", RuleRange, "circuit breaker durations must not be negative")
+		}
+	}
+
+	if c.NormalizePhoneNumbers && c.DefaultRegion != "" && phonenumber.CountryCodeForRegion(c.DefaultRegion) == 0 {
+		v.Add("default_region", RuleEnum, fmt.Sprintf("unsupported default region %q", c.DefaultRegion))
+	}
+
+	if c.LogLevel < LogLevelSilent || c.LogLevel > LogLevelDebug {
+		v.Add("log_level", RuleEnum, fmt.Sprintf("unknown log level %d", c.LogLevel))
+	}
+	if !validLogFormats[string(c.LogFormat)] {
+		v.Add("log_format", RuleEnum, fmt.Sprintf("unknown log format %q", c.LogFormat))
+	}
+
+	return v.Err()
+}
+
+func sortedRateLimitKeys(m map[string]RateLimit) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,177 @@
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCredentialRefreshInterval is how often a FileCredentialProvider re-reads its file
+const DefaultCredentialRefreshInterval = 30 * time.Second
+
+// CredentialProvider supplies the API key
+// APIKey is called before every request, so it should be cheap and safe for concurrent use
+type CredentialProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// CredentialRefresher is implemented by providers that can reload their key on demand
+// The client calls Refresh when the API rejects a key, before deciding whether to retry
+type CredentialRefresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// CredentialProviderFunc adapts a function to CredentialProvider, e.g. to read a secret manager cache
+type CredentialProviderFunc func(ctx context.Context) (string, error)
+
+// APIKey calls f
+func (f CredentialProviderFunc) APIKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticCredentialProvider holds a key that can be swapped at runtime
+type StaticCredentialProvider struct {
+	mu     sync.RWMutex
+	apiKey string
+}
+
+// NewStaticCredentialProvider creates a provider returning apiKey until SetAPIKey is called
+func NewStaticCredentialProvider(apiKey string) *StaticCredentialProvider {
+	return &StaticCredentialProvider{apiKey: apiKey}
+}
+
+// APIKey returns the current key
+func (p *StaticCredentialProvider) APIKey(context.Context) (string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.apiKey, nil
+}
+
+// SetAPIKey replaces the key; requests started afterwards use the new key
+func (p *StaticCredentialProvider) SetAPIKey(apiKey string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.apiKey = apiKey
+}
+
+// EnvCredentialProvider reads the key from an environment variable on every request
+type EnvCredentialProvider struct {
+	Name string // Variable name (default: GLIDE_API_KEY)
+}
+
+// APIKey returns the value of the environment variable
+func (p EnvCredentialProvider) APIKey(context.Context) (string, error) {
+	name := p.Name
+	if name == "" {
+		name = "GLIDE_API_KEY"
+	}
+	apiKey := os.Getenv(name)
+	if apiKey == "" {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return apiKey, nil
+}
+
+// FileCredentialProvider reads the key from a file, e.g. a mounted Kubernetes secret
+// The file is re-read at most once per interval, when a request needs the key, and on Refresh.
+// If a re-read fails, the last key read keeps being used.
+type FileCredentialProvider struct {
+	path     string
+	interval time.Duration
+
+	mu       sync.RWMutex
+	apiKey   string
+	loadedAt time.Time
+}
+
+// NewFileCredentialProvider reads the key from path, failing if the file cannot be read or is empty
+// interval <= 0 uses DefaultCredentialRefreshInterval
+func NewFileCredentialProvider(path string, interval time.Duration) (*FileCredentialProvider, error) {
+	if interval <= 0 {
+		interval = DefaultCredentialRefreshInterval
+	}
+	p := &FileCredentialProvider{path: path, interval: interval}
+	if err := p.Refresh(context.Background()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// APIKey returns the key, re-reading the file when the interval has passed
+func (p *FileCredentialProvider) APIKey(ctx context.Context) (string, error) {
+	p.mu.RLock()
+	apiKey, stale := p.apiKey, time.Since(p.loadedAt) >= p.interval
+	p.mu.RUnlock()
+
+	if stale {
+		if err := p.Refresh(ctx); err == nil {
+			p.mu.RLock()
+			apiKey = p.apiKey
+			p.mu.RUnlock()
+		}
+	}
+	return apiKey, nil
+}
+
+// Refresh re-reads the file now
+func (p *FileCredentialProvider) Refresh(context.Context) error {
+	data, err := os.ReadFile(p.path)
+	if err == nil && strings.TrimSpace(string(data)) == "" {
+		err = errors.New("file is empty")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Failed reads also wait a full interval, so a missing file is not read on every request
+	p.loadedAt = time.Now()
+	if err != nil {
+		return fmt.Errorf("failed to read API key file %s: %w", p.path, err)
+	}
+	p.apiKey = strings.TrimSpace(string(data))
+	return nil
+}
+
+// currentAPIKey returns the key for the next request, from the credential provider if one is set
+func (c *Client) currentAPIKey(ctx context.Context) (string, error) {
+	if c.config.Credentials == nil {
+		return c.config.APIKey, nil
+	}
+	apiKey, err := c.config.Credentials.APIKey(ctx)
+	if err != nil {
+		c.logger.Error("Failed to get API key from credential provider",
+			Field{"error", err.Error()},
+		)
+		return "", NewError(ErrCodeInternalServerError, "Failed to get API key")
+	}
+	return apiKey, nil
+}
+
+// credentialsRotated reports whether a call rejected with 401 should be retried once with a new key
+// It refreshes the provider and compares the key with the one the call used
+func (c *Client) credentialsRotated(ctx context.Context, err error, o *callOptions) bool {
+	var glideErr *Error
+	if c.config.Credentials == nil || o.credentialRetried || !errors.As(err, &glideErr) || glideErr.Status != 401 {
+		return false
+	}
+	o.credentialRetried = true
+
+	if refresher, ok := c.config.Credentials.(CredentialRefresher); ok {
+		if err := refresher.Refresh(ctx); err != nil {
+			c.logger.Warn("Failed to refresh credentials after authentication failure",
+				Field{"error", err.Error()},
+			)
+		}
+	}
+	apiKey, err := c.config.Credentials.APIKey(ctx)
+	if err != nil || apiKey == "" || apiKey == o.apiKey {
+		return false
+	}
+	c.logger.Info("API key was rotated, retrying with the new key",
+		Field{"idempotency_key", o.idempotencyKey},
+	)
+	return true
+}
@@ -0,0 +1,84 @@
+package glide
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// deviceStatusService implements the DeviceStatusService interface
+type deviceStatusService struct {
+	client *Client
+}
+
+// newDeviceStatusService creates a new DeviceStatus service
+func newDeviceStatusService(client *Client) DeviceStatusService {
+	return &deviceStatusService{
+		client: client,
+	}
+}
+
+// GetRoamingStatus checks if the device is currently roaming
+func (s *deviceStatusService) GetRoamingStatus(ctx context.Context, req *DeviceStatusRequest, opts ...CallOption) (*RoamingStatusResponse, error) {
+	// Validate request
+	phoneNumber, err := s.validateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build API request
+	apiReq := map[string]interface{}{
+		"phone_number": phoneNumber,
+	}
+
+	// Make API call
+	respData, err := s.client.doRequest(ctx, "POST", "/device-status/roaming", apiReq, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var resp RoamingStatusResponse
+	if err := json.Unmarshal(respData, &resp); err != nil {
+		return nil, NewError(ErrCodeInternalServerError, "Failed to parse response")
+	}
+
+	return &resp, nil
+}
+
+// GetConnectivityStatus checks if the device is reachable for data or SMS
+func (s *deviceStatusService) GetConnectivityStatus(ctx context.Context, req *DeviceStatusRequest, opts ...CallOption) (*ConnectivityStatusResponse, error) {
+	// Validate request
+	phoneNumber, err := s.validateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build API request
+	apiReq := map[string]interface{}{
+		"phone_number": phoneNumber,
+	}
+
+	// Make API call
+	respData, err := s.client.doRequest(ctx, "POST", "/device-status/connectivity", apiReq, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var resp ConnectivityStatusResponse
+	if err := json.Unmarshal(respData, &resp); err != nil {
+		return nil, NewError(ErrCodeInternalServerError, "Failed to parse response")
+	}
+
+	return &resp, nil
+}
+
+// validateRequest validates a device status request and returns the phone number to send
+func (s *deviceStatusService) validateRequest(req *DeviceStatusRequest) (string, error) {
+	if req.PhoneNumber == "" {
+		return "", NewError(ErrCodeMissingParameters, "Phone number is required")
+	}
+
+	// Validate phone number format (normalizing to E.164 if enabled)
+	return s.client.normalizePhoneNumber(req.PhoneNumber)
+}
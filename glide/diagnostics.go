@@ -0,0 +1,410 @@
+package glide
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	url2 "net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHealthCheckPath is the read-only endpoint probed by Ping and Diagnose
+// It answers a GET with 2xx when the API key is accepted, so probing never creates a session
+const DefaultHealthCheckPath = "/health"
+
+// MaxClockSkew is the difference from the server clock above which Diagnose warns
+const MaxClockSkew = 30 * time.Second
+
+// DiagnoseInterval is how long Diagnose reuses its last report, so frequent readiness
+// probes do not resolve, handshake and probe every endpoint on each call
+const DiagnoseInterval = 10 * time.Second
+
+// certificateExpiryWarning is how long before its expiry a server certificate is reported
+const certificateExpiryWarning = 14 * 24 * time.Hour
+
+// Diagnostic check names
+const (
+	CheckConfig   = "config"
+	CheckDNS      = "dns"
+	CheckTLS      = "tls"
+	CheckEndpoint = "endpoint"
+	CheckAuth     = "auth"
+	CheckClock    = "clock"
+)
+
+// DiagnosticStatus is the outcome of a diagnostic check
+type DiagnosticStatus string
+
+const (
+	DiagnosticOK      DiagnosticStatus = "ok"
+	DiagnosticWarning DiagnosticStatus = "warning" // Works, but needs attention
+	DiagnosticFailed  DiagnosticStatus = "failed"
+	DiagnosticSkipped DiagnosticStatus = "skipped" // Not applicable, or a check it depends on failed
+)
+
+// DiagnosticCheck is the result of one check
+type DiagnosticCheck struct {
+	Name     string           `json:"name"`
+	Endpoint string           `json:"endpoint,omitempty"` // Base URL, for network checks
+	Status   DiagnosticStatus `json:"status"`
+	Message  string           `json:"message"`
+	Duration time.Duration    `json:"duration_ns"`
+}
+
+// DiagnosticReport is the result of Diagnose
+type DiagnosticReport struct {
+	StartedAt time.Time         `json:"started_at"`
+	Duration  time.Duration     `json:"duration_ns"`
+	Checks    []DiagnosticCheck `json:"checks"`
+	ClockSkew time.Duration     `json:"clock_skew_ns"`        // Server clock minus local clock
+	RequestID string            `json:"request_id,omitempty"` // Glide request ID of the authenticated probe
+}
+
+// Healthy reports whether no check failed
+func (r *DiagnosticReport) Healthy() bool {
+	for _, check := range r.Checks {
+		if check.Status == DiagnosticFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// Check returns the first check with the given name, or nil
+func (r *DiagnosticReport) Check(name string) *DiagnosticCheck {
+	for i := range r.Checks {
+		if r.Checks[i].Name == name {
+			return &r.Checks[i]
+		}
+	}
+	return nil
+}
+
+// diagnosticsCache keeps the last Diagnose report
+// Its mutex also makes concurrent Diagnose calls share one run
+type diagnosticsCache struct {
+	mu     sync.Mutex
+	report *DiagnosticReport
+}
+
+// copy returns a copy of a report that the caller may modify
+func (r *DiagnosticReport) copy() *DiagnosticReport {
+	copied := *r
+	copied.Checks = append([]DiagnosticCheck(nil), r.Checks...)
+	return &copied
+}
+
+// probeResult is the outcome of one probe request
+type probeResult struct {
+	status  int
+	header  http.Header
+	body    []byte
+	start   time.Time
+	latency time.Duration
+}
+
+// Ping checks that the API is reachable and accepts the API key
+// It sends one read-only request to the endpoint calls would use, subject to rate limiting but
+// without retries or circuit breaking. Any answer other than 2xx is returned as an error.
+func (c *Client) Ping(ctx context.Context) error {
+	country, err := residencyCountry(c.config.DataResidency)
+	if err != nil {
+		return err
+	}
+	endpoint, err := c.endpoints.pick(country, nil)
+	if err != nil {
+		return err
+	}
+	apiKey, err := c.currentAPIKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	probe, err := c.probe(ctx, endpoint.URL, apiKey)
+	if err != nil {
+		// SDK transports such as Cassette and the rate limiter report their own errors
+		var transportErr *Error
+		if errors.As(err, &transportErr) {
+			return transportErr
+		}
+		return NewError(ErrCodeServiceUnavailable, "Failed to execute request")
+	}
+	switch {
+	case probe.status < 300:
+		return nil
+	case probe.status == http.StatusNotFound:
+		// Usually a wrong base URL, not a missing resource
+		return c.withResponseHeaders(NewErrorWithStatus(ErrCodeInternalServerError, "Health check endpoint not found, check the base URL", probe.status), probe.header)
+	default:
+		return c.withResponseHeaders(c.parseErrorResponse(probe.status, probe.body), probe.header)
+	}
+}
+
+// Diagnose checks the configuration and the network path to every endpoint
+// For each endpoint it resolves DNS, performs a TLS handshake and sends a probe request; the first
+// endpoint that answers is used to check the API key and the clock. With several endpoints, a
+// failing endpoint is a warning as long as another one passes. Diagnose does not return an error;
+// use Healthy on the report, e.g. in a readiness probe. A report younger than DiagnoseInterval
+// is returned again instead of running the checks, unless it was cut short by its context.
+func (c *Client) Diagnose(ctx context.Context) *DiagnosticReport {
+	c.diagnostics.mu.Lock()
+	defer c.diagnostics.mu.Unlock()
+
+	if last := c.diagnostics.report; last != nil && time.Since(last.StartedAt) < DiagnoseInterval {
+		return last.copy()
+	}
+	report := c.diagnose(ctx)
+	if ctx.Err() == nil {
+		c.diagnostics.report = report
+	}
+	return report.copy()
+}
+
+// diagnose runs every diagnostic check
+func (c *Client) diagnose(ctx context.Context) *DiagnosticReport {
+	report := &DiagnosticReport{StartedAt: time.Now()}
+	defer func() { report.Duration = time.Since(report.StartedAt) }()
+
+	start := time.Now()
+	if err := c.config.Validate(); err != nil {
+		report.add(DiagnosticCheck{Name: CheckConfig, Status: DiagnosticFailed, Message: err.Error()}, start)
+	} else {
+		report.add(DiagnosticCheck{Name: CheckConfig, Status: DiagnosticOK, Message: "configuration is valid"}, start)
+	}
+
+	apiKey, keyErr := c.currentAPIKey(ctx)
+
+	var answered *probeResult
+	var answeredURL string
+	var endpointChecks [][]int
+	healthyEndpoints := 0
+	for _, endpoint := range c.Endpoints() {
+		checks, probe := c.diagnoseEndpoint(ctx, report, endpoint.URL, apiKey)
+		endpointChecks = append(endpointChecks, checks)
+		if probe != nil && answered == nil {
+			answered, answeredURL = probe, endpoint.URL
+		}
+		if probe != nil && probeReachable(probe.status) {
+			healthyEndpoints++
+		}
+	}
+	if healthyEndpoints > 0 {
+		// Failover covers the failing endpoints
+		for _, checks := range endpointChecks {
+			for _, i := range checks {
+				if report.Checks[i].Status == DiagnosticFailed {
+					report.Checks[i].Status = DiagnosticWarning
+				}
+			}
+		}
+	}
+
+	start = time.Now()
+	auth := DiagnosticCheck{Name: CheckAuth, Endpoint: answeredURL}
+	switch {
+	case keyErr != nil:
+		auth.Status, auth.Message = DiagnosticFailed, keyErr.Error()
+	case answered == nil:
+		auth.Status, auth.Message = DiagnosticSkipped, "no endpoint answered"
+	case answered.status == http.StatusUnauthorized:
+		auth.Status, auth.Message = DiagnosticFailed, "API key was rejected"
+	case answered.status == http.StatusForbidden:
+		auth.Status, auth.Message = DiagnosticFailed, "API key is not allowed to use the API"
+	case answered.status < 300:
+		auth.Status, auth.Message = DiagnosticOK, "API key was accepted"
+		report.RequestID = answered.header.Get(requestIDHeader)
+	default:
+		// The endpoint check already failed
+		auth.Status, auth.Message = DiagnosticSkipped, fmt.Sprintf("endpoint returned HTTP %d", answered.status)
+	}
+	report.add(auth, start)
+
+	report.add(clockCheck(report, answered, answeredURL), time.Now())
+	return report
+}
+
+// diagnoseEndpoint runs the DNS, TLS and probe checks of an endpoint
+// It returns the indexes of its checks in the report and the probe result, nil if no response was received
+func (c *Client) diagnoseEndpoint(ctx context.Context, report *DiagnosticReport, baseURL, apiKey string) ([]int, *probeResult) {
+	var indexes []int
+	add := func(check DiagnosticCheck, start time.Time) {
+		check.Endpoint = baseURL
+		report.add(check, start)
+		indexes = append(indexes, len(report.Checks)-1)
+	}
+
+	start := time.Now()
+	u, err := url2.Parse(baseURL)
+	if err != nil || u.Hostname() == "" {
+		add(DiagnosticCheck{Name: CheckDNS, Status: DiagnosticFailed, Message: fmt.Sprintf("invalid endpoint URL %q", baseURL)}, start)
+		return indexes, nil
+	}
+	host := u.Hostname()
+
+	if ip := net.ParseIP(host); ip != nil {
+		add(DiagnosticCheck{Name: CheckDNS, Status: DiagnosticSkipped, Message: "endpoint is an IP address"}, start)
+	} else if addrs, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+		add(DiagnosticCheck{Name: CheckDNS, Status: DiagnosticFailed, Message: err.Error()}, start)
+		return indexes, nil
+	} else {
+		add(DiagnosticCheck{Name: CheckDNS, Status: DiagnosticOK, Message: "resolved to " + strings.Join(addrs, ", ")}, start)
+	}
+
+	start = time.Now()
+	if u.Scheme != "https" {
+		add(DiagnosticCheck{Name: CheckTLS, Status: DiagnosticSkipped, Message: "endpoint does not use TLS"}, start)
+	} else {
+		check := c.tlsCheck(ctx, u)
+		add(check, start)
+		if check.Status == DiagnosticFailed {
+			return indexes, nil
+		}
+	}
+
+	start = time.Now()
+	probe, err := c.probe(ctx, baseURL, apiKey)
+	var glideErr *Error
+	switch {
+	case errors.As(err, &glideErr) && glideErr.Code == ErrCodeRateLimitExceeded:
+		// Not the endpoint's fault, a readiness probe must not fail on it
+		add(DiagnosticCheck{Name: CheckEndpoint, Status: DiagnosticSkipped, Message: "probe skipped, client-side rate limit reached"}, start)
+		return indexes, nil
+	case err != nil:
+		add(DiagnosticCheck{Name: CheckEndpoint, Status: DiagnosticFailed, Message: err.Error()}, start)
+		return indexes, nil
+	case !probeReachable(probe.status):
+		add(DiagnosticCheck{Name: CheckEndpoint, Status: DiagnosticFailed,
+			Message: fmt.Sprintf("HTTP %d in %s", probe.status, probe.latency.Round(time.Millisecond))}, start)
+	default:
+		add(DiagnosticCheck{Name: CheckEndpoint, Status: DiagnosticOK,
+			Message: fmt.Sprintf("HTTP %d in %s", probe.status, probe.latency.Round(time.Millisecond))}, start)
+	}
+	return indexes, probe
+}
+
+// probeReachable reports whether a probe status shows a working health check endpoint
+// 401 and 403 mean it answered but rejected the API key, which the auth check reports
+func probeReachable(status int) bool {
+	return status < 300 || status == http.StatusUnauthorized || status == http.StatusForbidden
+}
+
+// tlsCheck performs a TLS handshake with the endpoint, using the TLS settings of the client's transport
+func (c *Client) tlsCheck(ctx context.Context, u *url2.URL) DiagnosticCheck {
+	config := &tls.Config{}
+	if transport, ok := c.httpClient.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		config = transport.TLSClientConfig.Clone()
+	}
+	if config.ServerName == "" {
+		config.ServerName = u.Hostname()
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	dialer := &tls.Dialer{Config: config}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return DiagnosticCheck{Name: CheckTLS, Status: DiagnosticFailed, Message: err.Error()}
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	check := DiagnosticCheck{Name: CheckTLS, Status: DiagnosticOK, Message: tls.VersionName(state.Version)}
+	if len(state.PeerCertificates) > 0 {
+		expires := state.PeerCertificates[0].NotAfter
+		check.Message += ", certificate valid until " + expires.UTC().Format(time.RFC3339)
+		if time.Until(expires) < certificateExpiryWarning {
+			check.Status = DiagnosticWarning
+		}
+	}
+	return check
+}
+
+// clockCheck compares the server's Date header with the local clock at the middle of the probe
+func clockCheck(report *DiagnosticReport, probe *probeResult, baseURL string) DiagnosticCheck {
+	check := DiagnosticCheck{Name: CheckClock, Endpoint: baseURL}
+	if probe == nil {
+		check.Status, check.Message = DiagnosticSkipped, "no endpoint answered"
+		return check
+	}
+	serverTime, err := http.ParseTime(probe.header.Get("Date"))
+	if err != nil {
+		check.Status, check.Message = DiagnosticSkipped, "response has no Date header"
+		return check
+	}
+
+	// The Date header has one second resolution
+	local := probe.start.Add(probe.latency / 2).Truncate(time.Second)
+	report.ClockSkew = serverTime.Sub(local)
+	check.Status, check.Message = DiagnosticOK, fmt.Sprintf("local clock is within %s of the server", MaxClockSkew)
+	if report.ClockSkew > MaxClockSkew || report.ClockSkew < -MaxClockSkew {
+		check.Status, check.Message = DiagnosticWarning, fmt.Sprintf("local clock differs from the server by %s", report.ClockSkew)
+	}
+	return check
+}
+
+// probe sends the health check request to an endpoint, through the client's rate limiter
+// Any HTTP response is a result; only transport failures are errors, without the request URL
+// since it carries the API key
+func (c *Client) probe(ctx context.Context, baseURL, apiKey string) (*probeResult, error) {
+	path := c.config.HealthCheckPath
+	if path == "" {
+		path = DefaultHealthCheckPath
+	}
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx, path); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			rateErr := NewError(ErrCodeRateLimitExceeded, "Client-side rate limit exceeded")
+			rateErr.cause = err
+			return nil, rateErr
+		}
+	}
+
+	url := baseURL + path
+	if apiKey != "" {
+		url += "?apikey=" + url2.QueryEscape(apiKey)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, NewError(ErrCodeInternalServerError, "Failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "glide-go-sdk/1.0.0")
+
+	result := &probeResult{start: time.Now()}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		var urlErr *url2.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		c.logger.Warn("Diagnostic request failed",
+			Field{"endpoint", baseURL},
+			Field{"error", err.Error()},
+		)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	c.quota.update(path, resp.Header, time.Now())
+
+	result.body, _ = io.ReadAll(resp.Body)
+	result.latency = time.Since(result.start)
+	result.status = resp.StatusCode
+	result.header = resp.Header
+	return result, nil
+}
+
+// add appends a check to the report, timing it from start
+func (r *DiagnosticReport) add(check DiagnosticCheck, start time.Time) {
+	check.Duration = time.Since(start)
+	r.Checks = append(r.Checks, check)
+}
@@ -0,0 +1,258 @@
+package glide
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultEndpointCooldown is how long a failed endpoint is skipped while others are available
+const DefaultEndpointCooldown = 30 * time.Second
+
+// endpointLatencyWeight is the weight of the newest sample in an endpoint's average latency
+const endpointLatencyWeight = 0.3
+
+// Endpoint is a regional API base URL
+type Endpoint struct {
+	URL    string // Base URL, e.g. "https://eu.api.glideidentity.app"
+	Region string // Name reported in ResponseMeta and logs, e.g. "eu"
+
+	// Countries whose data may be processed here, as ISO 3166-1 codes or names
+	// Only endpoints listing the DataResidency country are used when it is set
+	Countries []string
+}
+
+// serves reports whether the endpoint may process data of an ISO 3166-1 alpha-2 country
+func (e Endpoint) serves(country string) bool {
+	for _, c := range e.Countries {
+		if code, ok := NormalizeCountryCode(c); ok && code == country {
+			return true
+		}
+	}
+	return false
+}
+
+// EndpointPolicy selects the order in which endpoints are tried
+type EndpointPolicy int
+
+const (
+	// EndpointPolicyFailover uses the first healthy endpoint in list order, the rest are fallbacks
+	EndpointPolicyFailover EndpointPolicy = iota
+
+	// EndpointPolicyLowestLatency uses the healthy endpoint with the lowest average latency
+	// Endpoints without a measurement are tried first
+	EndpointPolicyLowestLatency
+)
+
+// String returns the config file name of the policy
+func (p EndpointPolicy) String() string {
+	switch p {
+	case EndpointPolicyFailover:
+		return "failover"
+	case EndpointPolicyLowestLatency:
+		return "lowest_latency"
+	default:
+		return fmt.Sprintf("EndpointPolicy(%d)", int(p))
+	}
+}
+
+// ParseEndpointPolicy converts "failover" or "lowest_latency" to an EndpointPolicy
+func ParseEndpointPolicy(policy string) (EndpointPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(policy)) {
+	case "failover", "primary":
+		return EndpointPolicyFailover, nil
+	case "lowest_latency", "latency":
+		return EndpointPolicyLowestLatency, nil
+	default:
+		return 0, fmt.Errorf("unknown endpoint policy %q (use failover or lowest_latency)", policy)
+	}
+}
+
+// EndpointStatus is the observed health of an endpoint
+type EndpointStatus struct {
+	Endpoint
+	Healthy  bool          // False while the endpoint is in its cooldown after a failure
+	Latency  time.Duration // Average latency of recent calls, 0 until one completes
+	Failures int           // Consecutive failed attempts
+}
+
+// endpointState tracks the health of one endpoint
+type endpointState struct {
+	Endpoint
+	index          int
+	latency        time.Duration
+	failures       int
+	unhealthyUntil time.Time
+}
+
+// endpointPool chooses the endpoint of each attempt
+type endpointPool struct {
+	policy   EndpointPolicy
+	cooldown time.Duration
+	logger   Logger
+
+	mu        sync.Mutex
+	endpoints []*endpointState
+}
+
+// newEndpointPool creates the pool for a configuration, using BaseURL when no endpoints are set
+func newEndpointPool(cfg *Config, logger Logger) *endpointPool {
+	endpoints := cfg.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = []Endpoint{{URL: cfg.BaseURL}}
+	}
+	p := &endpointPool{policy: cfg.EndpointPolicy, cooldown: cfg.EndpointCooldown, logger: logger}
+	for i, endpoint := range endpoints {
+		endpoint.URL = strings.TrimSuffix(endpoint.URL, "/")
+		p.endpoints = append(p.endpoints, &endpointState{Endpoint: endpoint, index: i})
+	}
+	return p
+}
+
+// pick returns the endpoint for the next attempt of a call
+// Healthy endpoints come first, ordered by the policy; endpoints the call already tried are
+// only reused when every candidate has been tried. country restricts the candidates.
+func (p *endpointPool) pick(country string, tried map[*endpointState]bool) (*endpointState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	candidates := make([]*endpointState, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		if country == "" || e.serves(country) {
+			candidates = append(candidates, e)
+		}
+	}
+	if len(candidates) == 0 {
+		v := &ValidationError{}
+		v.Add("data_residency", RuleEnum, fmt.Sprintf("No endpoint is configured for data residency in %s", country))
+		return nil, v.Err()
+	}
+
+	now := time.Now()
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if healthyA, healthyB := !now.Before(a.unhealthyUntil), !now.Before(b.unhealthyUntil); healthyA != healthyB {
+			return healthyA
+		}
+		if p.policy == EndpointPolicyLowestLatency && a.latency != b.latency {
+			return a.latency < b.latency
+		}
+		return a.index < b.index
+	})
+	for _, e := range candidates {
+		if !tried[e] {
+			return e, nil
+		}
+	}
+	return candidates[0], nil
+}
+
+// record updates the health of an endpoint after an attempt
+// Failures are transport errors and 5xx responses; other errors show the endpoint is reachable
+func (p *endpointPool) record(e *endpointState, failed bool, latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if failed {
+		e.failures++
+		e.unhealthyUntil = time.Now().Add(p.cooldown)
+		if len(p.endpoints) > 1 {
+			p.logger.Warn("Endpoint marked unhealthy",
+				Field{"endpoint", e.URL},
+				Field{"region", e.Region},
+				Field{"failures", e.failures},
+				Field{"cooldown", p.cooldown.String()},
+			)
+		}
+		return
+	}
+
+	e.failures = 0
+	e.unhealthyUntil = time.Time{}
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = time.Duration(endpointLatencyWeight*float64(latency) + (1-endpointLatencyWeight)*float64(e.latency))
+	}
+}
+
+// status returns the health of every endpoint, in configuration order
+func (p *endpointPool) status() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]EndpointStatus, len(p.endpoints))
+	for i, e := range p.endpoints {
+		statuses[i] = EndpointStatus{
+			Endpoint: e.Endpoint,
+			Healthy:  !now.Before(e.unhealthyUntil),
+			Latency:  e.latency,
+			Failures: e.failures,
+		}
+	}
+	return statuses
+}
+
+// Endpoints returns the observed health of the configured endpoints
+func (c *Client) Endpoints() []EndpointStatus {
+	return c.endpoints.status()
+}
+
+// isEndpointFailure reports whether an attempt's error counts against the endpoint that served it
+func isEndpointFailure(ctx context.Context, err error) bool {
+	return isCircuitFailure(ctx, err)
+}
+
+// residencyCountry normalizes the data residency country of a call, "" when it is not pinned
+func residencyCountry(country string) (string, error) {
+	if country == "" {
+		return "", nil
+	}
+	code, ok := NormalizeCountryCode(country)
+	if !ok {
+		v := &ValidationError{}
+		v.Add("data_residency", RuleEnum, fmt.Sprintf("Unknown data residency country %q", country))
+		return "", v.Err()
+	}
+	return code, nil
+}
+
+// validateEndpoints adds the problems with the endpoint settings to v
+func (c *Config) validateEndpoints(v *ValidationError) {
+	for i, endpoint := range c.Endpoints {
+		field := fmt.Sprintf("endpoints[%d]", i)
+		if u, err := url.Parse(endpoint.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			v.Add(field+".url", RuleURL, fmt.Sprintf("endpoint URL %q must be an absolute http or https URL", endpoint.URL))
+		}
+		for _, country := range endpoint.Countries {
+			if _, ok := NormalizeCountryCode(country); !ok {
+				v.Add(field+".countries", RuleEnum, fmt.Sprintf("unknown country %q", country))
+			}
+		}
+	}
+	if c.EndpointPolicy != EndpointPolicyFailover && c.EndpointPolicy != EndpointPolicyLowestLatency {
+		v.Add("endpoint_policy", RuleEnum, fmt.Sprintf("unknown endpoint policy %s", c.EndpointPolicy))
+	}
+	if c.EndpointCooldown < 0 {
+		v.Add("endpoint_cooldown", RuleRange, "endpoint cooldown must not be negative")
+	}
+	if c.DataResidency == "" {
+		return
+	}
+	country, ok := NormalizeCountryCode(c.DataResidency)
+	if !ok {
+		v.Add("data_residency", RuleEnum, fmt.Sprintf("unknown country %q", c.DataResidency))
+		return
+	}
+	for _, endpoint := range c.Endpoints {
+		if endpoint.serves(country) {
+			return
+		}
+	}
+	v.Add("data_residency", RuleEnum, fmt.Sprintf("no endpoint is configured for data residency in %s", country))
+}
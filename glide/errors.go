@@ -2,6 +2,7 @@ package glide
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Error codes - Only codes that the server actually returns to clients
@@ -33,13 +34,25 @@ const (
 	ErrCodeServiceUnavailable = "SERVICE_UNAVAILABLE"
 )
 
+// SDK-side error codes (never returned by the server)
+const (
+	// ErrCodeCircuitOpen is returned when the circuit breaker rejects a call
+	ErrCodeCircuitOpen = "CIRCUIT_OPEN"
+
+	// ErrCodeCassetteMismatch is returned when a replaying Cassette has no recording for a request
+	ErrCodeCassetteMismatch = "CASSETTE_MISMATCH"
+)
+
 // Error represents an error returned by the Glide API
 type Error struct {
-	Code      string                 `json:"code"`
-	Message   string                 `json:"message"`
-	Status    int                    `json:"status,omitempty"`
-	RequestID string                 `json:"request_id,omitempty"`
-	Details   map[string]interface{} `json:"details,omitempty"`
+	Code           string                 `json:"code"`
+	Message        string                 `json:"message"`
+	Status         int                    `json:"status,omitempty"`
+	RequestID      string                 `json:"request_id,omitempty"`
+	IdempotencyKey string                 `json:"idempotency_key,omitempty"` // Key sent with the failed call
+	Details        map[string]interface{} `json:"details,omitempty"`
+
+	cause error // Underlying error returned by Unwrap
 }
 
 // Error implements the error interface
@@ -50,11 +63,141 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+// Unwrap returns the underlying error, e.g. the *ValidationError of a validation failure
+// or ErrCircuitOpen for calls rejected by the circuit breaker
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
 // IsCode checks if the error matches a specific error code
 func (e *Error) IsCode(code string) bool {
 	return e.Code == code
 }
 
+// Violations returns the per-field validation problems attached to the error, if any
+// Works for both SDK-side validation errors and server errors decoded from JSON
+func (e *Error) Violations() []FieldViolation {
+	switch v := e.Details["violations"].(type) {
+	case []FieldViolation:
+		return v
+	case []interface{}:
+		violations := make([]FieldViolation, 0, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			field, _ := m["field"].(string)
+			rule, _ := m["rule"].(string)
+			message, _ := m["message"].(string)
+			violations = append(violations, FieldViolation{Field: field, Rule: rule, Message: message})
+		}
+		return violations
+	}
+	return nil
+}
+
+// Validation rules used in FieldViolation.Rule
+const (
+	RuleRequired  = "required"
+	RuleForbidden = "forbidden"
+	RuleFormat    = "format"
+	RuleMinLength = "min_length"
+	RuleMaxLength = "max_length"
+	RuleRange     = "range"
+	RuleEnum      = "enum"
+	RuleURL       = "url"
+	RuleRegistry  = "registry"
+)
+
+// FieldViolation describes a single invalid request field
+type FieldViolation struct {
+	Field   string `json:"field"`   // Field path, e.g. "plmn.mcc" or "area.boundary[2].latitude"
+	Rule    string `json:"rule"`    // Rule that failed, e.g. "required" or "format"
+	Message string `json:"message"` // Human-readable message
+}
+
+// ValidationError collects field violations so that all problems are reported at once
+type ValidationError struct {
+	Violations []FieldViolation
+	missing    int // Violations recorded through AddMissing
+}
+
+// Add records a violation
+func (v *ValidationError) Add(field, rule, message string) {
+	v.Violations = append(v.Violations, FieldViolation{Field: field, Rule: rule, Message: message})
+}
+
+// AddMissing records a missing top-level parameter
+func (v *ValidationError) AddMissing(field, message string) {
+	v.Add(field, RuleRequired, message)
+	v.missing++
+}
+
+// HasViolations reports whether any violation was recorded
+func (v *ValidationError) HasViolations() bool {
+	return len(v.Violations) > 0
+}
+
+// Error implements the error interface
+func (v *ValidationError) Error() string {
+	messages := make([]string, len(v.Violations))
+	for i, violation := range v.Violations {
+		messages[i] = violation.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Err converts the collected violations into an *Error, or returns nil if there are none
+// The code is MISSING_PARAMETERS when only parameters are missing, VALIDATION_ERROR otherwise.
+// Its Message is the first violation; all of them are in Violations.
+// The *Error wraps a copy of v, so errors.As(err, &validationErr) works on the result.
+func (v *ValidationError) Err() error {
+	if !v.HasViolations() {
+		return nil
+	}
+
+	code := ErrCodeValidationError
+	if v.missing == len(v.Violations) {
+		code = ErrCodeMissingParameters
+	}
+
+	// Snapshot the violations so later Add calls do not change the returned error
+	// The message stays the first problem, as single-field validators always reported it
+	snapshot := &ValidationError{Violations: append([]FieldViolation(nil), v.Violations...), missing: v.missing}
+	return &Error{
+		Code:    code,
+		Message: snapshot.Violations[0].Message,
+		Details: map[string]interface{}{"violations": snapshot.Violations},
+		cause:   snapshot,
+	}
+}
+
+// merge appends the violations carried by err, if any
+// Errors without field information are recorded against the given field
+func (v *ValidationError) merge(field string, err error) {
+	if err == nil {
+		return
+	}
+	glideErr, ok := err.(*Error)
+	if !ok {
+		v.Add(field, RuleFormat, err.Error())
+		return
+	}
+
+	violations := glideErr.Violations()
+	if len(violations) == 0 {
+		violations = []FieldViolation{{Field: field, Rule: RuleFormat, Message: glideErr.Message}}
+		if glideErr.Code == ErrCodeMissingParameters {
+			violations[0].Rule = RuleRequired
+		}
+	}
+	v.Violations = append(v.Violations, violations...)
+	if glideErr.Code == ErrCodeMissingParameters {
+		v.missing += len(violations)
+	}
+}
+
 // NewError creates a new Error with the given code and message
 func NewError(code, message string) *Error {
 	return &Error{
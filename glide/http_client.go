@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -22,44 +23,177 @@ type httpTransport struct {
 }
 
 // doRequest performs an HTTP request with retry logic
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+// The same Idempotency-Key is sent on every attempt of a logical call
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, opts ...CallOption) (respData []byte, err error) {
+	o := c.newCallOptions(opts)
+	start := time.Now()
+	defer func() { o.fillMeta(start, err) }()
+
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	if o.idempotencyKey == "" && c.config.AutoIdempotencyKeys {
+		o.idempotencyKey = newIdempotencyKey()
+	}
+
+	// Non-idempotent operations are only retried when the server can deduplicate them
+	retryCount := o.retryCount
+	policy := retryPolicyFor(path)
+	if policy == retryWithKey && o.idempotencyKey == "" && retryCount > 0 {
+		c.logger.Debug("Retries disabled for non-idempotent operation without idempotency key",
+			Field{"path", path},
+		)
+		retryCount = 0
+	}
+
+	// Calls pinned to a country only go to endpoints serving it
+	country, err := residencyCountry(o.dataResidency)
+	if err != nil {
+		return nil, err
+	}
+	tried := make(map[*endpointState]bool)
+	if o.endpoint, err = c.endpoints.pick(country, tried); err != nil {
+		return nil, err
+	}
+
+	// Fail fast while the operation's circuit is open
+	var breaker *circuitBreaker
+	var ticket circuitTicket
+	if c.breakers != nil {
+		var allowed bool
+		breaker = c.breakers.get(path)
+		if ticket, allowed = breaker.allow(); !allowed {
+			return nil, c.circuitOpenError(breaker, o)
+		}
+	}
+
 	// Apply rate limiting if enabled
-	if c.config.RateLimitEnabled && c.rateLimiter != nil {
+	if c.rateLimiter != nil {
 		c.logger.Debug("Applying rate limiting",
 			Field{"method", method},
 			Field{"path", path},
 		)
-		if err := c.rateLimiter.Wait(ctx); err != nil {
+		waitStart := time.Now()
+		err := c.rateLimiter.Wait(withMaxWait(ctx, c.callTimeout(o)), path)
+		o.rateLimitWait = time.Since(waitStart)
+		if err != nil {
+			if breaker != nil {
+				breaker.release(ticket)
+			}
+			// A cancelled call is not rate limited and must not look retryable
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				c.logger.Error("Request cancelled while waiting for the rate limiter",
+					Field{"error", ctxErr.Error()},
+				)
+				rateErr := NewError(ErrCodeInternalServerError, "Request cancelled")
+				rateErr.cause = ctxErr
+				return nil, withIdempotencyKey(rateErr, o.idempotencyKey)
+			}
 			c.logger.Error("Rate limit exceeded",
 				Field{"error", err.Error()},
 			)
-			return nil, NewError(ErrCodeRateLimitExceeded, "Client-side rate limit exceeded")
+			rateErr := NewError(ErrCodeRateLimitExceeded, "Client-side rate limit exceeded")
+			rateErr.cause = err
+			var quotaErr *quotaExceededError
+			if errors.As(err, &quotaErr) {
+				rateErr.Message = "Server quota exhausted"
+				rateErr.Details = map[string]interface{}{
+					"retry_after": quotaErr.retryAfter.Round(time.Second).Seconds(),
+				}
+			}
+			return nil, withIdempotencyKey(rateErr, o.idempotencyKey)
 		}
 	}
 
 	var lastErr error
-	for attempt := 0; attempt <= c.config.RetryCount; attempt++ {
+	endpointFailed := false // Whether the last attempt failed because of its endpoint
+	for attempt := 0; attempt <= retryCount; attempt++ {
 		// Add retry delay (except for first attempt)
 		if attempt > 0 {
+			next := o.endpoint
+			if endpointFailed {
+				next, _ = c.endpoints.pick(country, tried)
+			}
+			delay := o.retryDelay * time.Duration(attempt)
+			if backoff := c.quota.backoff(path, time.Now()); backoff > delay {
+				if backoff > c.callTimeout(o) {
+					// The last error carries retry_after, leave the wait to the caller
+					break
+				}
+				delay = backoff // Honor the server's Retry-After
+			}
+			if !tried[next] {
+				// Another endpoint can be tried right away
+				c.logger.Warn("Failing over to another endpoint",
+					Field{"from", o.endpoint.URL},
+					Field{"to", next.URL},
+					Field{"region", next.Region},
+				)
+				delay = 0
+			}
+			o.endpoint = next
 			c.logger.Debug("Retrying request",
 				Field{"attempt", attempt},
-				Field{"delay", c.config.RetryDelay * time.Duration(attempt)},
+				Field{"delay", delay},
+				Field{"idempotency_key", o.idempotencyKey},
+				Field{"retry_policy", policy.String()},
+				Field{"endpoint", next.URL},
 			)
 			select {
-			case <-time.After(c.config.RetryDelay * time.Duration(attempt)):
+			case <-time.After(delay):
 			case <-ctx.Done():
 				c.logger.Error("Request cancelled during retry",
 					Field{"attempt", attempt},
+					Field{"idempotency_key", o.idempotencyKey},
 				)
-				return nil, NewError(ErrCodeInternalServerError, "Request cancelled")
+				return nil, withIdempotencyKey(NewError(ErrCodeInternalServerError, "Request cancelled"), o.idempotencyKey)
+			}
+			if breaker != nil {
+				var allowed bool
+				if ticket, allowed = breaker.allow(); !allowed {
+					return nil, c.circuitOpenError(breaker, o)
+				}
 			}
 		}
 
 		// Perform the request
-		respData, err := c.performRequest(ctx, method, path, body)
+		o.attempts++
+		tried[o.endpoint] = true
+		attemptStart := time.Now()
+		respData, err := c.performRequest(ctx, method, path, body, o)
+		if c.credentialsRotated(ctx, err, o) {
+			// The key was rotated while the request was in flight, retry once with the new one
+			o.attempts++
+			respData, err = c.performRequest(ctx, method, path, body, o)
+		}
+		endpointFailed = isEndpointFailure(ctx, err)
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			c.endpoints.record(o.endpoint, endpointFailed, time.Since(attemptStart))
+		}
+		if breaker != nil {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				breaker.release(ticket)
+			} else {
+				breaker.record(ticket, !isCircuitFailure(ctx, err))
+			}
+		}
 		if err == nil {
 			return respData, nil
 		}
+		err = withIdempotencyKey(err, o.idempotencyKey)
+
+		// Stop retrying once the caller's deadline has passed
+		if ctx.Err() != nil {
+			c.logger.Error("Request deadline exceeded",
+				Field{"attempt", attempt},
+				Field{"error", ctx.Err().Error()},
+				Field{"idempotency_key", o.idempotencyKey},
+			)
+			return nil, err
+		}
 
 		// Check if error is retryable
 		if glideErr, ok := err.(*Error); ok {
@@ -67,6 +201,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 				c.logger.Error("Non-retryable error",
 					Field{"error", glideErr.Error()},
 					Field{"code", glideErr.Code},
+					Field{"idempotency_key", o.idempotencyKey},
 				)
 				return nil, err
 			}
@@ -74,6 +209,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 				Field{"error", glideErr.Error()},
 				Field{"code", glideErr.Code},
 				Field{"attempt", attempt},
+				Field{"idempotency_key", o.idempotencyKey},
 			)
 		}
 
@@ -82,21 +218,39 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 
 	c.logger.Error("All retry attempts exhausted",
 		Field{"lastError", lastErr.Error()},
-		Field{"retryCount", c.config.RetryCount},
+		Field{"retryCount", retryCount},
+		Field{"idempotency_key", o.idempotencyKey},
 	)
 	return nil, lastErr
 }
 
+// circuitOpenError logs a rejected call and builds its error
+func (c *Client) circuitOpenError(breaker *circuitBreaker, o *callOptions) error {
+	err := newCircuitOpenError(breaker)
+	c.logger.Warn("Request rejected by open circuit breaker",
+		Field{"operation", breaker.operation},
+		Field{"retry_after", err.Details["retry_after"]},
+		Field{"idempotency_key", o.idempotencyKey},
+	)
+	return withIdempotencyKey(err, o.idempotencyKey)
+}
+
 // performRequest executes a single HTTP request
-func (c *Client) performRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+func (c *Client) performRequest(ctx context.Context, method, path string, body interface{}, o *callOptions) ([]byte, error) {
+	apiKey, err := c.currentAPIKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	o.apiKey = apiKey
+
 	// Build URL with API key as query parameter
-	url := c.config.BaseURL + path
-	if c.config.APIKey != "" {
+	url := o.endpoint.URL + path
+	if apiKey != "" {
 		// Add API key as query parameter (like Node SDK)
 		if strings.Contains(url, "?") {
-			url += "&apikey=" + url2.QueryEscape(c.config.APIKey)
+			url += "&apikey=" + url2.QueryEscape(apiKey)
 		} else {
-			url += "?apikey=" + url2.QueryEscape(c.config.APIKey)
+			url += "?apikey=" + url2.QueryEscape(apiKey)
 		}
 	}
 
@@ -126,6 +280,7 @@ func (c *Client) performRequest(ctx context.Context, method, path string, body i
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "glide-go-sdk/1.0.0")
+	o.applyHeaders(req)
 
 	// Track timing
 	start := time.Now()
@@ -134,11 +289,14 @@ func (c *Client) performRequest(ctx context.Context, method, path string, body i
 	if dl, ok := c.logger.(*defaultLogger); ok && dl.formatter != nil && dl.format == LogFormatPretty {
 		// Show formatted request
 		operation := getOperationFromURL(url)
-		fmt.Printf("\n========== %s REQUEST ==========\n", operation)
+		fmt.Fprintf(dl.out, "\n========== %s REQUEST ==========\n", operation)
+
+		// The logged URL leaves out the API key query parameter
+		logURL := o.endpoint.URL + path
 
 		// Build request object for pretty printing
 		reqObj := map[string]interface{}{
-			"url":    url,
+			"url":    logURL,
 			"method": method,
 			"headers": map[string]string{
 				"Content-Type": "application/json",
@@ -150,9 +308,9 @@ func (c *Client) performRequest(ctx context.Context, method, path string, body i
 		}
 
 		if jsonBytes, err := json.MarshalIndent(reqObj, "", "  "); err == nil {
-			fmt.Println(string(jsonBytes))
+			fmt.Fprintln(dl.out, string(jsonBytes))
 		}
-		fmt.Println("================================================")
+		fmt.Fprintln(dl.out, "================================================")
 
 		// Then show the box summary
 		details := make(map[string]interface{})
@@ -171,7 +329,7 @@ func (c *Client) performRequest(ctx context.Context, method, path string, body i
 				}
 			}
 		}
-		dl.formatter.FormatRequest(method, url, details)
+		dl.formatter.FormatRequest(method, logURL, details)
 	}
 
 	// Execute request
@@ -183,10 +341,20 @@ func (c *Client) performRequest(ctx context.Context, method, path string, body i
 			Field{"error", err.Error()},
 			Field{"elapsed", elapsed.String()},
 		)
+		// SDK transports such as Cassette report their own errors
+		var transportErr *Error
+		if errors.As(err, &transportErr) {
+			return nil, transportErr
+		}
 		return nil, NewError(ErrCodeServiceUnavailable, "Failed to execute request")
 	}
 	defer resp.Body.Close()
 
+	// Track the server quota from rate limit headers
+	c.quota.update(path, resp.Header, time.Now())
+	o.status = resp.StatusCode
+	o.header = resp.Header
+
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -202,7 +370,7 @@ func (c *Client) performRequest(ctx context.Context, method, path string, body i
 		operation := getOperationFromURL(url)
 
 		// Show formatted response
-		fmt.Printf("\n========== %s RESPONSE ==========\n", operation)
+		fmt.Fprintf(dl.out, "\n========== %s RESPONSE ==========\n", operation)
 
 		// Build response object for pretty printing
 		respObj := map[string]interface{}{
@@ -213,14 +381,18 @@ func (c *Client) performRequest(ctx context.Context, method, path string, body i
 		if len(respBody) > 0 {
 			var bodyData interface{}
 			if err := json.Unmarshal(respBody, &bodyData); err == nil {
+				// Identity data must never be printed in clear text
+				if strings.Contains(url, "kyc-fill-in") {
+					bodyData = redactJSONValues(bodyData)
+				}
 				respObj["body"] = bodyData
 			}
 		}
 
 		if jsonBytes, err := json.MarshalIndent(respObj, "", "  "); err == nil {
-			fmt.Println(string(jsonBytes))
+			fmt.Fprintln(dl.out, string(jsonBytes))
 		}
-		fmt.Println("=================================================")
+		fmt.Fprintln(dl.out, "=================================================")
 
 		// Then show the box summary
 		details := make(map[string]interface{})
@@ -249,7 +421,7 @@ func (c *Client) performRequest(ctx context.Context, method, path string, body i
 			}
 		}
 		dl.formatter.FormatResponse(operation, resp.StatusCode, details)
-		fmt.Println() // Add spacing after box
+		fmt.Fprintln(dl.out) // Add spacing after box
 	}
 
 	// Check for errors
@@ -263,13 +435,14 @@ func (c *Client) performRequest(ctx context.Context, method, path string, body i
 		if dl, ok := c.logger.(*defaultLogger); !ok || dl.format != LogFormatPretty {
 			c.logger.Debug("Error response body", Field{"body", string(respBody)})
 		}
-		return nil, c.parseErrorResponse(resp.StatusCode, respBody)
+		return nil, c.withResponseHeaders(c.parseErrorResponse(resp.StatusCode, respBody), resp.Header)
 	}
 
 	if dl, ok := c.logger.(*defaultLogger); !ok || dl.format != LogFormatPretty {
 		c.logger.Info("Request completed successfully",
 			Field{"statusCode", resp.StatusCode},
 			Field{"elapsed", elapsed.String()},
+			Field{"request_id", resp.Header.Get(requestIDHeader)},
 		)
 	}
 
@@ -304,6 +477,29 @@ func (c *Client) parseErrorResponse(statusCode int, body []byte) error {
 	return c.genericErrorForStatus(statusCode)
 }
 
+// withResponseHeaders adds the request ID and Retry-After headers to an error when the body did not include them
+func (c *Client) withResponseHeaders(err error, header http.Header) error {
+	glideErr, ok := err.(*Error)
+	if !ok {
+		return err
+	}
+	if glideErr.RequestID == "" {
+		glideErr.RequestID = header.Get(requestIDHeader)
+	}
+	retryAfter, ok := parseRetryAfter(header.Get("Retry-After"), time.Now())
+	if !ok {
+		return err
+	}
+	if _, exists := glideErr.Details["retry_after"]; exists {
+		return err
+	}
+	if glideErr.Details == nil {
+		glideErr.Details = make(map[string]interface{})
+	}
+	glideErr.Details["retry_after"] = time.Until(retryAfter).Round(time.Second).Seconds()
+	return err
+}
+
 // genericErrorForStatus creates a generic error based on HTTP status
 func (c *Client) genericErrorForStatus(status int) error {
 	switch status {
@@ -332,12 +528,34 @@ func (c *Client) genericErrorForStatus(status int) error {
 	}
 }
 
+// callTimeout returns the timeout of a call, WithCallTimeout or else the client timeout
+func (c *Client) callTimeout(o *callOptions) time.Duration {
+	if o.timeout > 0 {
+		return o.timeout
+	}
+	return c.config.Timeout
+}
+
 // initRateLimiter initializes the rate limiter if configured
+// With quota pacing, the server quota is applied before the configured limiter
 func (c *Client) initRateLimiter() {
+	var limiter RateLimiter
 	if c.config.RateLimitEnabled {
-		limit := rate.Every(c.config.RateLimitPeriod / time.Duration(c.config.RateLimitRate))
-		c.rateLimiter = rate.NewLimiter(limit, c.config.RateLimitRate)
+		if c.config.RateLimiter != nil {
+			limiter = c.config.RateLimiter
+		} else {
+			limits := RateLimits{Operations: c.config.OperationRateLimits}
+			if global := (RateLimit{Rate: c.config.RateLimitRate, Period: c.config.RateLimitPeriod}); global.valid() {
+				limits.Global = &global
+			}
+			limiter = NewLocalRateLimiter(limits)
+		}
+	}
+
+	if c.config.QuotaPacing {
+		limiter = &quotaRateLimiter{quota: c.quota, maxWait: c.config.Timeout, next: limiter}
 	}
+	c.rateLimiter = limiter
 }
 
 // getOperationFromURL extracts operation name from URL for logging
@@ -355,6 +573,17 @@ func getOperationFromURL(url string) string {
 		return "SimSwap RETRIEVE DATE"
 	} else if strings.Contains(url, "kyc-match") {
 		return "KYC MATCH"
+	} else if strings.Contains(url, "kyc-age-verification") {
+		return "KYC AGE VERIFY"
+	} else if strings.Contains(url, "kyc-fill-in") {
+		return "KYC FILL-IN"
+	} else if strings.Contains(url, "location-verification") {
+		return "Location VERIFY"
+	} else if strings.Contains(url, "device-status") {
+		if strings.Contains(url, "roaming") {
+			return "DeviceStatus ROAMING"
+		}
+		return "DeviceStatus CONNECTIVITY"
 	}
 	return "API Request"
 }
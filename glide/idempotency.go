@@ -0,0 +1,75 @@
+package glide
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// retryPolicy classifies whether an operation may be retried automatically
+type retryPolicy int
+
+const (
+	// retryWithKey operations create sessions or are billed per call, so they
+	// are only retried when an Idempotency-Key lets the server deduplicate them
+	retryWithKey retryPolicy = iota
+	// retrySafe operations are read-only lookups that can always be retried
+	retrySafe
+)
+
+// String returns the string representation of a retryPolicy
+func (p retryPolicy) String() string {
+	if p == retrySafe {
+		return "safe"
+	}
+	return "idempotency_key"
+}
+
+// operationRetryPolicies lists the retry policy per endpoint
+// Endpoints not listed here default to retryWithKey
+var operationRetryPolicies = map[string]retryPolicy{
+	"/magic-auth/v2/auth/prepare":             retryWithKey, // Creates a session
+	"/magic-auth/v2/auth/verify-phone-number": retryWithKey, // Consumes the credential
+	"/magic-auth/v2/auth/get-phone-number":    retryWithKey, // Consumes the credential
+	"/number-verify/verify":                   retryWithKey, // Consumes the network token
+	"/kyc-match/match":                        retryWithKey, // Billed per call
+	"/kyc-age-verification/verify":            retryWithKey, // Billed per call
+	"/kyc-fill-in/retrieve":                   retryWithKey, // Billed per call
+	"/sim-swap/check":                         retrySafe,
+	"/sim-swap/retrieve-date":                 retrySafe,
+	"/device-status/roaming":                  retrySafe,
+	"/device-status/connectivity":             retrySafe,
+	"/location-verification/verify":           retrySafe,
+}
+
+// retryPolicyFor returns the retry policy for a request path
+func retryPolicyFor(path string) retryPolicy {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	if policy, ok := operationRetryPolicies[path]; ok {
+		return policy
+	}
+	return retryWithKey
+}
+
+// newIdempotencyKey generates a random UUID v4 for an Idempotency-Key header
+func newIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	s := hex.EncodeToString(b[:])
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// withIdempotencyKey records the idempotency key on SDK errors
+func withIdempotencyKey(err error, key string) error {
+	if glideErr, ok := err.(*Error); ok && key != "" {
+		glideErr.IdempotencyKey = key
+	}
+	return err
+}
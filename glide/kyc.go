@@ -3,6 +3,8 @@ package glide
 import (
 	"context"
 	"encoding/json"
+	"strings"
+	"time"
 )
 
 // kycService implements the KYCService interface
@@ -18,33 +20,131 @@ func newKYCService(client *Client) KYCService {
 }
 
 // Match verifies user identity information
-func (s *kycService) Match(ctx context.Context, req *KYCMatchRequest) (*KYCMatchResponse, error) {
-	// Validate request
+func (s *kycService) Match(ctx context.Context, req *KYCMatchRequest, opts ...CallOption) (*KYCMatchResponse, error) {
+	// Validate request, reporting all field problems together
+	v := &ValidationError{}
 	if req.PhoneNumber == "" {
-		return nil, NewError(ErrCodeMissingParameters, "Phone number is required")
+		v.AddMissing("phone_number", "Phone number is required")
 	}
 
-	// Validate phone number format
-	if err := ValidatePhoneNumber(req.PhoneNumber); err != nil {
-		return nil, err
+	// Validate phone number format (normalizing to E.164 if enabled)
+	phoneNumber, err := s.client.normalizePhoneNumber(req.PhoneNumber)
+	v.merge("phone_number", err)
+
+	// Normalize input to improve match rates, hashed values must always be normalized
+	if s.client.config.KYCNormalize || s.client.config.KYCHashing {
+		req = normalizeKYCMatchRequest(req, v)
+	}
+
+	// Validate dates if provided
+	if req.BirthDate != "" && !isValidDate(req.BirthDate) {
+		v.Add("birth_date", RuleFormat, "Birth date must be in YYYY-MM-DD format")
+	}
+	if req.IDDocumentExpiryDate != "" && !isValidDate(req.IDDocumentExpiryDate) {
+		v.Add("id_document_expiry_date", RuleFormat, "ID document expiry date must be in YYYY-MM-DD format")
+	}
+
+	// Validate gender if provided
+	if req.Gender != "" && req.Gender != GenderMale && req.Gender != GenderFemale && req.Gender != GenderOther {
+		v.Add("gender", RuleEnum, "Gender must be MALE, FEMALE or OTHER")
+	}
+
+	// Build API request - only include non-empty fields
+	apiReq := map[string]interface{}{
+		"phone_number": phoneNumber,
+	}
+
+	optionalFields := map[string]string{
+		"name":                    req.Name,
+		"given_name":              req.GivenName,
+		"family_name":             req.FamilyName,
+		"middle_names":            req.MiddleNames,
+		"family_name_at_birth":    req.FamilyNameAtBirth,
+		"name_kana_hankaku":       req.NameKanaHankaku,
+		"name_kana_zenkaku":       req.NameKanaZenkaku,
+		"birth_date":              req.BirthDate,
+		"gender":                  string(req.Gender),
+		"nationality":             req.Nationality,
+		"email":                   req.Email,
+		"id_document":             req.IDDocument,
+		"id_document_type":        req.IDDocumentType,
+		"id_document_expiry_date": req.IDDocumentExpiryDate,
+	}
+	for key, value := range optionalFields {
+		if value != "" {
+			apiReq[key] = value
+		}
+	}
+	if req.Address != nil {
+		apiReq["address"] = req.Address
 	}
 
 	// At least one field besides phone number should be provided for matching
-	if req.Name == "" && req.GivenName == "" && req.FamilyName == "" &&
-		req.BirthDate == "" && req.Email == "" && req.Address == nil && req.IDDocument == "" {
-		return nil, NewError(ErrCodeMissingParameters, "At least one field to match is required")
+	if len(apiReq) == 1 {
+		v.AddMissing("match_fields", "At least one field to match is required")
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	// Send hashed values for fields that support it
+	if s.client.config.KYCHashing {
+		hashKYCFields(apiReq)
+	}
+
+	// Make API call
+	respData, err := s.client.doRequest(ctx, "POST", "/kyc-match/match", apiReq, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var resp KYCMatchResponse
+	if err := json.Unmarshal(respData, &resp); err != nil {
+		return nil, NewError(ErrCodeInternalServerError, "Failed to parse response")
+	}
+
+	syncMatchResults(resp.MatchResults)
+
+	return &resp, nil
+}
+
+// AgeVerify checks if the subscriber is above an age threshold
+func (s *kycService) AgeVerify(ctx context.Context, req *KYCAgeVerifyRequest, opts ...CallOption) (*KYCAgeVerifyResponse, error) {
+	// Validate request, reporting all field problems together
+	v := &ValidationError{}
+	if req.PhoneNumber == "" {
+		v.AddMissing("phone_number", "Phone number is required")
+	}
+
+	// Validate phone number format (normalizing to E.164 if enabled)
+	phoneNumber, err := s.client.normalizePhoneNumber(req.PhoneNumber)
+	v.merge("phone_number", err)
+
+	if req.AgeThreshold == 0 {
+		v.AddMissing("age_threshold", "Age threshold is required")
+	} else if req.AgeThreshold < 1 || req.AgeThreshold > 120 {
+		v.Add("age_threshold", RuleRange, "Age threshold must be between 1 and 120")
 	}
 
 	// Validate birth date format if provided
-	if req.BirthDate != "" && !isValidDateFormat(req.BirthDate) {
-		return nil, NewError(ErrCodeValidationError, "Birth date must be in YYYY-MM-DD format")
+	if req.BirthDate != "" && !isValidDate(req.BirthDate) {
+		v.Add("birth_date", RuleFormat, "Birth date must be in YYYY-MM-DD format")
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 
 	// Build API request - only include non-empty fields
 	apiReq := map[string]interface{}{
-		"phone_number": req.PhoneNumber,
+		"phone_number":  phoneNumber,
+		"age_threshold": req.AgeThreshold,
 	}
 
+	if req.IDDocument != "" {
+		apiReq["id_document"] = req.IDDocument
+	}
 	if req.Name != "" {
 		apiReq["name"] = req.Name
 	}
@@ -54,54 +154,100 @@ func (s *kycService) Match(ctx context.Context, req *KYCMatchRequest) (*KYCMatch
 	if req.FamilyName != "" {
 		apiReq["family_name"] = req.FamilyName
 	}
+	if req.MiddleNames != "" {
+		apiReq["middle_names"] = req.MiddleNames
+	}
+	if req.FamilyNameAtBirth != "" {
+		apiReq["family_name_at_birth"] = req.FamilyNameAtBirth
+	}
 	if req.BirthDate != "" {
 		apiReq["birth_date"] = req.BirthDate
 	}
 	if req.Email != "" {
 		apiReq["email"] = req.Email
 	}
-	if req.Address != nil {
-		apiReq["address"] = req.Address
+	if req.IncludeContentLock {
+		apiReq["include_content_lock"] = true
 	}
-	if req.IDDocument != "" {
-		apiReq["id_document"] = req.IDDocument
+	if req.IncludeParentalControl {
+		apiReq["include_parental_control"] = true
 	}
 
 	// Make API call
-	respData, err := s.client.doRequest(ctx, "POST", "/kyc-match/match", apiReq)
+	respData, err := s.client.doRequest(ctx, "POST", "/kyc-age-verification/verify", apiReq, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	// Parse response
-	var resp KYCMatchResponse
+	var resp KYCAgeVerifyResponse
 	if err := json.Unmarshal(respData, &resp); err != nil {
 		return nil, NewError(ErrCodeInternalServerError, "Failed to parse response")
 	}
 
+	syncMatchResults(resp.MatchResults)
+
 	return &resp, nil
 }
 
-// isValidDateFormat checks if a date string is in YYYY-MM-DD format
-func isValidDateFormat(date string) bool {
-	// Simple validation for YYYY-MM-DD format
-	if len(date) != 10 {
-		return false
+// FillIn retrieves the subscriber identity held by the operator
+func (s *kycService) FillIn(ctx context.Context, req *KYCFillInRequest, opts ...CallOption) (*KYCFillInResponse, error) {
+	// Validate request
+	if req.PhoneNumber == "" {
+		return nil, NewError(ErrCodeMissingParameters, "Phone number is required")
 	}
 
-	if date[4] != '-' || date[7] != '-' {
-		return false
+	// Validate phone number format (normalizing to E.164 if enabled)
+	phoneNumber, err := s.client.normalizePhoneNumber(req.PhoneNumber)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check year, month, day are digits
-	for i, c := range date {
-		if i == 4 || i == 7 {
-			continue // Skip hyphens
-		}
-		if c < '0' || c > '9' {
-			return false
+	// Sharing identity data requires explicit user consent
+	if req.ConsentData == nil {
+		return nil, NewError(ErrCodeMissingParameters, "Consent data is required for KYC fill-in")
+	}
+	if err := ValidateConsentData(req.ConsentData); err != nil {
+		return nil, err
+	}
+
+	// Build API request
+	apiReq := map[string]interface{}{
+		"phone_number": phoneNumber,
+		"consent_data": req.ConsentData,
+	}
+
+	// Make API call
+	respData, err := s.client.doRequest(ctx, "POST", "/kyc-fill-in/retrieve", apiReq, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var resp KYCFillInResponse
+	if err := json.Unmarshal(respData, &resp); err != nil {
+		return nil, NewError(ErrCodeInternalServerError, "Failed to parse response")
+	}
+
+	s.client.logger.Debug("KYC fill-in completed",
+		Field{"availableFields", strings.Join(resp.Identity.AvailableFields(), ",")},
+	)
+
+	return &resp, nil
+}
+
+// syncMatchResults keeps the legacy Matched flag consistent with the tri-state result
+func syncMatchResults(results map[string]MatchResult) {
+	for field, result := range results {
+		if result.Result != "" {
+			result.Matched = result.Result == KYCCheckTrue
+			results[field] = result
 		}
 	}
+}
 
-	return true
+// isValidDate checks if a date string is a real calendar date in YYYY-MM-DD format
+func isValidDate(date string) bool {
+	_, err := time.Parse("2006-01-02", date)
+	return err == nil
 }
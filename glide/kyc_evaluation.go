@@ -0,0 +1,103 @@
+package glide
+
+import (
+	"sort"
+)
+
+// DefaultMatchScoreThreshold is the minimum match score accepted for partial matches
+const DefaultMatchScoreThreshold = 80
+
+// MatchThresholds configures how KYC match results are evaluated
+type MatchThresholds struct {
+	// Default is the minimum match score for partial matches (DefaultMatchScoreThreshold if zero)
+	Default int
+
+	// Fields overrides the minimum match score per field, keyed by API field name
+	Fields map[string]int
+
+	// RequiredFields must be present and pass; not_available fails a required field
+	RequiredFields []string
+}
+
+// MatchEvaluation is the outcome of evaluating a KYC match response
+type MatchEvaluation struct {
+	Passed       bool
+	Matched      []string // Fields that matched exactly
+	Partial      []string // Fields accepted by match score
+	Failed       []string // Fields that did not match or are missing
+	NotAvailable []string // Optional fields the operator could not check
+}
+
+// Status returns the tri-state result, falling back to Matched for older responses
+func (r MatchResult) Status() KYCCheckResult {
+	if r.Result != "" {
+		return r.Result
+	}
+	if r.Matched {
+		return KYCCheckTrue
+	}
+	return KYCCheckFalse
+}
+
+// Evaluate checks every field result against the given thresholds
+func (r *KYCMatchResponse) Evaluate(thresholds MatchThresholds) MatchEvaluation {
+	return evaluateMatchResults(r.MatchResults, thresholds)
+}
+
+// Evaluate checks the identity field results of an age verification against the given thresholds
+// The age check itself is reported by IsAboveThreshold
+func (r *KYCAgeVerifyResponse) Evaluate(thresholds MatchThresholds) MatchEvaluation {
+	return evaluateMatchResults(r.MatchResults, thresholds)
+}
+
+// evaluateMatchResults checks per-field match results against thresholds
+func evaluateMatchResults(results map[string]MatchResult, thresholds MatchThresholds) MatchEvaluation {
+	var eval MatchEvaluation
+
+	required := make(map[string]bool, len(thresholds.RequiredFields))
+	for _, field := range thresholds.RequiredFields {
+		required[field] = true
+		if _, exists := results[field]; !exists {
+			eval.Failed = append(eval.Failed, field)
+		}
+	}
+
+	for field, result := range results {
+		switch result.Status() {
+		case KYCCheckTrue:
+			eval.Matched = append(eval.Matched, field)
+		case KYCCheckNotAvailable:
+			if required[field] {
+				eval.Failed = append(eval.Failed, field)
+			} else {
+				eval.NotAvailable = append(eval.NotAvailable, field)
+			}
+		default:
+			if result.MatchScore != nil && *result.MatchScore >= thresholds.scoreFor(field) {
+				eval.Partial = append(eval.Partial, field)
+			} else {
+				eval.Failed = append(eval.Failed, field)
+			}
+		}
+	}
+
+	// Map iteration order is random, keep output stable
+	sort.Strings(eval.Matched)
+	sort.Strings(eval.Partial)
+	sort.Strings(eval.Failed)
+	sort.Strings(eval.NotAvailable)
+
+	eval.Passed = len(eval.Failed) == 0
+	return eval
+}
+
+// scoreFor returns the minimum match score for a field
+func (t MatchThresholds) scoreFor(field string) int {
+	if score, ok := t.Fields[field]; ok {
+		return score
+	}
+	if t.Default > 0 {
+		return t.Default
+	}
+	return DefaultMatchScoreThreshold
+}
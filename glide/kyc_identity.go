@@ -0,0 +1,183 @@
+package glide
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// KYCField holds a single identity attribute returned by the operator
+// Available is false when the operator has no value for the field
+type KYCField struct {
+	Value     string
+	Available bool
+
+	// Raw holds values the operator sent as a number, boolean, object or array
+	// Value then contains their compact JSON text
+	Raw json.RawMessage
+}
+
+// String returns a redacted representation so the value never ends up in logs
+func (f KYCField) String() string {
+	if !f.Available {
+		return "<not available>"
+	}
+	return redactPII(f.Value)
+}
+
+// GoString returns a redacted representation for %#v formatting
+func (f KYCField) GoString() string {
+	return f.String()
+}
+
+// MarshalJSON encodes the value, or null when the field is not available
+func (f KYCField) MarshalJSON() ([]byte, error) {
+	if !f.Available {
+		return []byte("null"), nil
+	}
+	if f.Raw != nil {
+		return f.Raw, nil
+	}
+	return json.Marshal(f.Value)
+}
+
+// UnmarshalJSON decodes a value, treating null and empty strings as not available
+// Non-string values are kept in Raw so one unexpected field does not fail the whole response
+func (f *KYCField) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*f = KYCField{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*f = KYCField{Value: value, Available: value != ""}
+		return nil
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return err
+	}
+	*f = KYCField{Value: compact.String(), Available: true, Raw: json.RawMessage(compact.Bytes())}
+	return nil
+}
+
+// KYCIdentity contains the subscriber identity held by the operator
+type KYCIdentity struct {
+	IDDocument           KYCField `json:"id_document"`
+	IDDocumentType       KYCField `json:"id_document_type"`
+	IDDocumentExpiryDate KYCField `json:"id_document_expiry_date"`
+
+	Name              KYCField `json:"name"`
+	GivenName         KYCField `json:"given_name"`
+	FamilyName        KYCField `json:"family_name"`
+	MiddleNames       KYCField `json:"middle_names"`
+	FamilyNameAtBirth KYCField `json:"family_name_at_birth"`
+	BirthDate         KYCField `json:"birth_date"` // Format: YYYY-MM-DD
+	Email             KYCField `json:"email"`
+
+	Address      KYCField `json:"address"`
+	StreetName   KYCField `json:"street_name"`
+	StreetNumber KYCField `json:"street_number"`
+	PostalCode   KYCField `json:"postal_code"`
+	Region       KYCField `json:"region"`
+	Locality     KYCField `json:"locality"`
+	Country      KYCField `json:"country"`
+}
+
+// Fields returns all identity fields keyed by their API name
+func (i KYCIdentity) Fields() map[string]KYCField {
+	fields := make(map[string]KYCField)
+	for _, f := range i.orderedFields() {
+		fields[f.name] = f.field
+	}
+	return fields
+}
+
+// AvailableFields returns the API names of the fields the operator provided
+func (i KYCIdentity) AvailableFields() []string {
+	var names []string
+	for _, f := range i.orderedFields() {
+		if f.field.Available {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// String returns a redacted representation so PII never ends up in logs
+func (i KYCIdentity) String() string {
+	parts := make([]string, 0, len(i.orderedFields()))
+	for _, f := range i.orderedFields() {
+		if f.field.Available {
+			parts = append(parts, fmt.Sprintf("%s=%s", f.name, f.field))
+		}
+	}
+	return "KYCIdentity{" + strings.Join(parts, " ") + "}"
+}
+
+// GoString returns a redacted representation for %#v formatting
+func (i KYCIdentity) GoString() string {
+	return i.String()
+}
+
+type namedKYCField struct {
+	name  string
+	field KYCField
+}
+
+// orderedFields lists the identity fields in a stable order
+func (i KYCIdentity) orderedFields() []namedKYCField {
+	return []namedKYCField{
+		{"id_document", i.IDDocument},
+		{"id_document_type", i.IDDocumentType},
+		{"id_document_expiry_date", i.IDDocumentExpiryDate},
+		{"name", i.Name},
+		{"given_name", i.GivenName},
+		{"family_name", i.FamilyName},
+		{"middle_names", i.MiddleNames},
+		{"family_name_at_birth", i.FamilyNameAtBirth},
+		{"birth_date", i.BirthDate},
+		{"email", i.Email},
+		{"address", i.Address},
+		{"street_name", i.StreetName},
+		{"street_number", i.StreetNumber},
+		{"postal_code", i.PostalCode},
+		{"region", i.Region},
+		{"locality", i.Locality},
+		{"country", i.Country},
+	}
+}
+
+// redactPII masks a personal value, keeping only the first character
+func redactPII(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 1 {
+		return "****[REDACTED]"
+	}
+	return string(runes[:1]) + "****[REDACTED]"
+}
+
+// redactJSONValues replaces every string in a decoded JSON value with its redacted form
+func redactJSONValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			redacted[key] = redactJSONValues(item)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for idx, item := range v {
+			redacted[idx] = redactJSONValues(item)
+		}
+		return redacted
+	case string:
+		return redactPII(v)
+	default:
+		return v
+	}
+}
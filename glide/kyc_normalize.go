@@ -0,0 +1,197 @@
+package glide
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// streetAbbreviations expands common address abbreviations
+var streetAbbreviations = map[string]string{
+	"st":   "street",
+	"str":  "street",
+	"ave":  "avenue",
+	"av":   "avenue",
+	"rd":   "road",
+	"blvd": "boulevard",
+	"dr":   "drive",
+	"ln":   "lane",
+	"ct":   "court",
+	"pl":   "place",
+	"sq":   "square",
+	"ter":  "terrace",
+	"hwy":  "highway",
+	"pkwy": "parkway",
+	"cir":  "circle",
+	"apt":  "apartment",
+	"ste":  "suite",
+	"fl":   "floor",
+	"bldg": "building",
+}
+
+// hashableKYCFields lists the match fields the API accepts as SHA-256 hashes
+var hashableKYCFields = map[string]bool{
+	"name":                 true,
+	"given_name":           true,
+	"family_name":          true,
+	"middle_names":         true,
+	"family_name_at_birth": true,
+	"name_kana_hankaku":    true,
+	"name_kana_zenkaku":    true,
+	"birth_date":           true,
+	"email":                true,
+	"id_document":          true,
+}
+
+var caseFolder = cases.Fold()
+
+// normalizeText applies NFKC, collapses whitespace and folds case
+func normalizeText(value string) string {
+	value = norm.NFKC.String(value)
+	value = strings.Join(strings.Fields(value), " ")
+	return caseFolder.String(value)
+}
+
+// normalizeKana collapses whitespace only, NFKC would turn half-width kana into full-width
+func normalizeKana(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// normalizeEmail applies NFKC, trims and lower-cases an email address
+func normalizeEmail(value string) string {
+	return strings.ToLower(strings.TrimSpace(norm.NFKC.String(value)))
+}
+
+// normalizeIdentifier applies NFKC, removes whitespace and upper-cases document numbers and postal codes
+func normalizeIdentifier(value string) string {
+	value = norm.NFKC.String(value)
+	return strings.ToUpper(strings.Join(strings.Fields(value), ""))
+}
+
+// unitAbbreviations are the abbreviations that may follow the street type, as in "Main St Apt 4"
+var unitAbbreviations = map[string]bool{"apt": true, "ste": true, "fl": true, "bldg": true}
+
+// streetDirections may follow the street type, as in "5th Ave NW"
+var streetDirections = map[string]bool{
+	"n": true, "s": true, "e": true, "w": true,
+	"ne": true, "nw": true, "se": true, "sw": true,
+	"north": true, "south": true, "east": true, "west": true,
+}
+
+// normalizeStreet normalizes a street name and expands abbreviations like "St." to "street"
+// Street types are only expanded in suffix position: "St" and "Dr" also abbreviate "Saint"
+// and "Doctor" when they start a name, as in "Dr Martin Luther King Blvd"
+func normalizeStreet(value string) string {
+	var words []string
+	for _, word := range strings.Fields(normalizeText(value)) {
+		// Stray punctuation such as "Main St , Apt 4" leaves nothing to keep
+		if word = strings.TrimRight(word, ".,"); word != "" {
+			words = append(words, word)
+		}
+	}
+	for i, word := range words {
+		if !unitAbbreviations[word] && (i == 0 || !endsStreetName(words[i+1:])) {
+			continue
+		}
+		if expanded, ok := streetAbbreviations[word]; ok {
+			words[i] = expanded
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// endsStreetName reports whether the words after a street type are only a direction or unit
+func endsStreetName(rest []string) bool {
+	if len(rest) == 0 {
+		return true
+	}
+	next := rest[0]
+	return streetDirections[next] || unitAbbreviations[next] || (next[0] >= '0' && next[0] <= '9')
+}
+
+// normalizeKYCMatchRequest returns a normalized copy of the request, recording invalid values in v
+func normalizeKYCMatchRequest(req *KYCMatchRequest, v *ValidationError) *KYCMatchRequest {
+	normalized := *req
+
+	normalized.Name = normalizeText(req.Name)
+	normalized.GivenName = normalizeText(req.GivenName)
+	normalized.FamilyName = normalizeText(req.FamilyName)
+	normalized.MiddleNames = normalizeText(req.MiddleNames)
+	normalized.FamilyNameAtBirth = normalizeText(req.FamilyNameAtBirth)
+	normalized.NameKanaHankaku = normalizeKana(req.NameKanaHankaku)
+	normalized.NameKanaZenkaku = normalizeKana(req.NameKanaZenkaku)
+	normalized.BirthDate = strings.TrimSpace(req.BirthDate)
+	normalized.Gender = Gender(strings.ToUpper(strings.TrimSpace(string(req.Gender))))
+	normalized.Email = normalizeEmail(req.Email)
+	normalized.IDDocument = normalizeIdentifier(req.IDDocument)
+	normalized.IDDocumentType = normalizeText(req.IDDocumentType)
+	normalized.IDDocumentExpiryDate = strings.TrimSpace(req.IDDocumentExpiryDate)
+
+	if strings.TrimSpace(req.Nationality) != "" {
+		code, ok := NormalizeCountryCode(req.Nationality)
+		if ok {
+			normalized.Nationality = code
+		} else {
+			v.Add("nationality", RuleEnum, "Nationality must be a valid ISO 3166-1 country")
+		}
+	}
+
+	if req.Address != nil {
+		address := Address{
+			Street:               normalizeStreet(req.Address.Street),
+			HouseNumber:          normalizeIdentifier(req.Address.HouseNumber),
+			HouseNumberExtension: normalizeIdentifier(req.Address.HouseNumberExtension),
+			City:                 normalizeText(req.Address.City),
+			State:                normalizeText(req.Address.State),
+			Region:               normalizeText(req.Address.Region),
+			PostalCode:           normalizeIdentifier(req.Address.PostalCode),
+		}
+		if strings.TrimSpace(req.Address.Country) != "" {
+			code, ok := NormalizeCountryCode(req.Address.Country)
+			if ok {
+				address.Country = code
+			} else {
+				v.Add("address.country", RuleEnum, "Address country must be a valid ISO 3166-1 country")
+			}
+		}
+		normalized.Address = &address
+	}
+
+	return &normalized
+}
+
+// hashKYCValue returns the lower-case hex SHA-256 digest of a value
+func hashKYCValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashKYCFields replaces hashable values in an API request with their SHA-256 digest
+func hashKYCFields(apiReq map[string]interface{}) {
+	for key, value := range apiReq {
+		if str, ok := value.(string); ok && hashableKYCFields[key] {
+			apiReq[key] = hashKYCValue(str)
+		}
+	}
+
+	// Street level address components support hashing, country and region stay in clear
+	if address, ok := apiReq["address"].(*Address); ok {
+		hashed := *address
+		if hashed.Street != "" {
+			hashed.Street = hashKYCValue(hashed.Street)
+		}
+		if hashed.HouseNumber != "" {
+			hashed.HouseNumber = hashKYCValue(hashed.HouseNumber)
+		}
+		if hashed.HouseNumberExtension != "" {
+			hashed.HouseNumberExtension = hashKYCValue(hashed.HouseNumberExtension)
+		}
+		if hashed.PostalCode != "" {
+			hashed.PostalCode = hashKYCValue(hashed.PostalCode)
+		}
+		apiReq["address"] = &hashed
+	}
+}
@@ -0,0 +1,66 @@
+package glide
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// locationVerificationService implements the LocationVerificationService interface
+type locationVerificationService struct {
+	client *Client
+}
+
+// newLocationVerificationService creates a new LocationVerification service
+func newLocationVerificationService(client *Client) LocationVerificationService {
+	return &locationVerificationService{
+		client: client,
+	}
+}
+
+// Verify checks if the device is within the given area
+// maxAge is the maximum acceptable age of the location in seconds (0 uses the server default)
+func (s *locationVerificationService) Verify(ctx context.Context, phoneNumber string, area *LocationArea, maxAge int, opts ...CallOption) (*LocationVerifyResponse, error) {
+	// Validate request
+	if phoneNumber == "" {
+		return nil, NewError(ErrCodeMissingParameters, "Phone number is required")
+	}
+
+	// Validate phone number format (normalizing to E.164 if enabled)
+	phoneNumber, err := s.client.normalizePhoneNumber(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate area
+	if err := ValidateLocationArea(area); err != nil {
+		return nil, err
+	}
+
+	if maxAge < 0 {
+		return nil, NewError(ErrCodeValidationError, "Max age must not be negative")
+	}
+
+	// Build API request
+	apiReq := map[string]interface{}{
+		"phone_number": phoneNumber,
+		"area":         area,
+	}
+
+	if maxAge > 0 {
+		apiReq["max_age"] = maxAge
+	}
+
+	// Make API call
+	respData, err := s.client.doRequest(ctx, "POST", "/location-verification/verify", apiReq, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var resp LocationVerifyResponse
+	if err := json.Unmarshal(respData, &resp); err != nil {
+		return nil, NewError(ErrCodeInternalServerError, "Failed to parse response")
+	}
+
+	return &resp, nil
+}
@@ -3,6 +3,7 @@ package glide
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strings"
@@ -251,6 +252,7 @@ func min(a, b int) int {
 type LogFormatter struct {
 	format LogFormat
 	prefix string
+	out    io.Writer
 }
 
 // NewLogFormatter creates a new log formatter
@@ -258,6 +260,7 @@ func NewLogFormatter(format LogFormat, prefix string) *LogFormatter {
 	return &LogFormatter{
 		format: format,
 		prefix: prefix,
+		out:    os.Stdout,
 	}
 }
 
@@ -327,8 +330,8 @@ func (f *LogFormatter) formatRequestPretty(method, url string, details map[strin
 
 	// Create and print the box
 	box := createBox("→ "+operation, content, colors.Cyan)
-	fmt.Println()
-	fmt.Println(box)
+	fmt.Fprintln(f.out)
+	fmt.Fprintln(f.out, box)
 }
 
 func (f *LogFormatter) formatResponsePretty(operation string, status int, details map[string]interface{}) {
@@ -364,29 +367,29 @@ func (f *LogFormatter) formatResponsePretty(operation string, status int, detail
 	// Create and print the box
 	title := fmt.Sprintf("%s %s Response", symbol, operation)
 	box := createBox(title, content, color)
-	fmt.Println(box)
-	fmt.Println()
+	fmt.Fprintln(f.out, box)
+	fmt.Fprintln(f.out)
 }
 
 // Simple format implementations
 func (f *LogFormatter) formatRequestSimple(method, url string, details map[string]interface{}) {
-	fmt.Printf("[%s] %s %s", time.Now().Format("15:04:05"), method, url)
+	fmt.Fprintf(f.out, "[%s] %s %s", time.Now().Format("15:04:05"), method, url)
 	if len(details) > 0 {
 		if jsonBytes, err := json.Marshal(details); err == nil {
-			fmt.Printf(" %s", string(jsonBytes))
+			fmt.Fprintf(f.out, " %s", string(jsonBytes))
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(f.out)
 }
 
 func (f *LogFormatter) formatResponseSimple(operation string, status int, details map[string]interface{}) {
-	fmt.Printf("[%s] Response %d", time.Now().Format("15:04:05"), status)
+	fmt.Fprintf(f.out, "[%s] Response %d", time.Now().Format("15:04:05"), status)
 	if len(details) > 0 {
 		if jsonBytes, err := json.Marshal(details); err == nil {
-			fmt.Printf(" %s", string(jsonBytes))
+			fmt.Fprintf(f.out, " %s", string(jsonBytes))
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(f.out)
 }
 
 // JSON format implementations
@@ -399,7 +402,7 @@ func (f *LogFormatter) formatRequestJSON(method, url string, details map[string]
 		"details":   details,
 	}
 	if jsonBytes, err := json.Marshal(logObj); err == nil {
-		fmt.Println(string(jsonBytes))
+		fmt.Fprintln(f.out, string(jsonBytes))
 	}
 }
 
@@ -412,7 +415,7 @@ func (f *LogFormatter) formatResponseJSON(operation string, status int, details
 		"details":   details,
 	}
 	if jsonBytes, err := json.Marshal(logObj); err == nil {
-		fmt.Println(string(jsonBytes))
+		fmt.Fprintln(f.out, string(jsonBytes))
 	}
 }
 
@@ -431,6 +434,17 @@ func (f *LogFormatter) getOperationName(url string) string {
 		return "SimSwap RETRIEVE DATE"
 	} else if strings.Contains(url, "kyc-match") {
 		return "KYC MATCH"
+	} else if strings.Contains(url, "kyc-age-verification") {
+		return "KYC AGE VERIFY"
+	} else if strings.Contains(url, "kyc-fill-in") {
+		return "KYC FILL-IN"
+	} else if strings.Contains(url, "location-verification") {
+		return "Location VERIFY"
+	} else if strings.Contains(url, "device-status") {
+		if strings.Contains(url, "roaming") {
+			return "DeviceStatus ROAMING"
+		}
+		return "DeviceStatus CONNECTIVITY"
 	}
 	return "API Request"
 }
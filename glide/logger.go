@@ -2,6 +2,7 @@ package glide
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"regexp"
@@ -46,6 +47,7 @@ type defaultLogger struct {
 	timeFormat string
 	formatter  *LogFormatter
 	format     LogFormat
+	out        io.Writer
 }
 
 // NewDefaultLogger creates a new default logger with the specified level
@@ -55,12 +57,23 @@ func NewDefaultLogger(level LogLevel) Logger {
 
 // NewDefaultLoggerWithFormat creates a new default logger with specified level and format
 func NewDefaultLoggerWithFormat(level LogLevel, format LogFormat) Logger {
+	return NewDefaultLoggerWithOutput(level, format, os.Stdout)
+}
+
+// NewDefaultLoggerWithOutput creates a new default logger that writes to out instead of stdout
+func NewDefaultLoggerWithOutput(level LogLevel, format LogFormat, out io.Writer) Logger {
+	if out == nil {
+		out = os.Stdout
+	}
+	formatter := NewLogFormatter(format, "[Glide]")
+	formatter.out = out
 	return &defaultLogger{
 		level:      level,
-		logger:     log.New(os.Stdout, "[Glide] ", 0),
+		logger:     log.New(out, "[Glide] ", 0),
 		timeFormat: time.RFC3339,
-		formatter:  NewLogFormatter(format, "[Glide]"),
+		formatter:  formatter,
 		format:     format,
+		out:        out,
 	}
 }
 
@@ -20,9 +20,10 @@ func newMagicAuthService(client *Client) MagicAuthService {
 }
 
 // Prepare initiates the authentication flow
-func (s *magicAuthService) Prepare(ctx context.Context, req *PrepareRequest) (*PrepareResponse, error) {
+func (s *magicAuthService) Prepare(ctx context.Context, req *PrepareRequest, opts ...CallOption) (*PrepareResponse, error) {
 	// Validate request
-	if err := s.validatePrepareRequest(req); err != nil {
+	phoneNumber, err := s.validatePrepareRequest(req)
+	if err != nil {
 		return nil, err
 	}
 
@@ -36,8 +37,8 @@ func (s *magicAuthService) Prepare(ctx context.Context, req *PrepareRequest) (*P
 		"use_case": string(req.UseCase),
 	}
 
-	if req.PhoneNumber != "" {
-		apiReq["phone_number"] = req.PhoneNumber
+	if phoneNumber != "" {
+		apiReq["phone_number"] = phoneNumber
 	}
 
 	// Add PLMN as nested object to match Node.js SDK structure
@@ -58,7 +59,7 @@ func (s *magicAuthService) Prepare(ctx context.Context, req *PrepareRequest) (*P
 	}
 
 	// Make API call
-	respData, err := s.client.doRequest(ctx, "POST", "/magic-auth/v2/auth/prepare", apiReq)
+	respData, err := s.client.doRequest(ctx, "POST", "/magic-auth/v2/auth/prepare", apiReq, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +78,7 @@ func (s *magicAuthService) Prepare(ctx context.Context, req *PrepareRequest) (*P
 }
 
 // VerifyPhoneNumber verifies a phone number using the credential from Digital Credentials API
-func (s *magicAuthService) VerifyPhoneNumber(ctx context.Context, req *VerifyPhoneNumberRequest) (*VerifyPhoneNumberResponse, error) {
+func (s *magicAuthService) VerifyPhoneNumber(ctx context.Context, req *VerifyPhoneNumberRequest, opts ...CallOption) (*VerifyPhoneNumberResponse, error) {
 	// Validate request
 	if req.Session == nil {
 		return nil, NewError(ErrCodeMissingParameters, "Session is required")
@@ -96,7 +97,7 @@ func (s *magicAuthService) VerifyPhoneNumber(ctx context.Context, req *VerifyPho
 	// Call the verify endpoint
 	endpoint := "/magic-auth/v2/auth/verify-phone-number"
 
-	respData, err := s.client.doRequest(ctx, "POST", endpoint, apiReq)
+	respData, err := s.client.doRequest(ctx, "POST", endpoint, apiReq, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +112,7 @@ func (s *magicAuthService) VerifyPhoneNumber(ctx context.Context, req *VerifyPho
 }
 
 // GetPhoneNumber retrieves the phone number using the credential from Digital Credentials API
-func (s *magicAuthService) GetPhoneNumber(ctx context.Context, req *GetPhoneNumberRequest) (*GetPhoneNumberResponse, error) {
+func (s *magicAuthService) GetPhoneNumber(ctx context.Context, req *GetPhoneNumberRequest, opts ...CallOption) (*GetPhoneNumberResponse, error) {
 	// Validate request
 	if req.Session == nil {
 		return nil, NewError(ErrCodeMissingParameters, "Session is required")
@@ -130,7 +131,7 @@ func (s *magicAuthService) GetPhoneNumber(ctx context.Context, req *GetPhoneNumb
 	// Call the get phone number endpoint
 	endpoint := "/magic-auth/v2/auth/get-phone-number"
 
-	respData, err := s.client.doRequest(ctx, "POST", endpoint, apiReq)
+	respData, err := s.client.doRequest(ctx, "POST", endpoint, apiReq, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -184,38 +185,45 @@ func (s *magicAuthService) extractCredentialString(credential interface{}) strin
 	return ""
 }
 
-// validatePrepareRequest validates the prepare request
-func (s *magicAuthService) validatePrepareRequest(req *PrepareRequest) error {
-	// Validate use case
-	if req.UseCase != UseCaseGetPhoneNumber && req.UseCase != UseCaseVerifyPhoneNumber {
-		return NewError(ErrCodeValidationError, "Invalid use case")
-	}
+// validatePrepareRequest validates the prepare request and returns the phone number to send
+// All field problems are reported together
+func (s *magicAuthService) validatePrepareRequest(req *PrepareRequest) (string, error) {
+	v := &ValidationError{}
 
-	// Validate use case requirements (handles the business logic)
-	if err := ValidateUseCaseRequirements(req.UseCase, req.PhoneNumber, req.PLMN); err != nil {
-		return err
+	// Validate use case and its requirements (handles the business logic)
+	if req.UseCase != UseCaseGetPhoneNumber && req.UseCase != UseCaseVerifyPhoneNumber {
+		v.Add("use_case", RuleEnum, "Invalid use case")
+	} else {
+		checkUseCaseRequirements(v, req.UseCase, req.PhoneNumber, req.PLMN)
 	}
 
-	// Validate phone number format if provided
-	if req.PhoneNumber != "" {
-		if err := ValidatePhoneNumber(req.PhoneNumber); err != nil {
-			return err
-		}
-	}
+	// Validate phone number format if provided (normalizing to E.164 if enabled)
+	phoneNumber, err := s.client.normalizePhoneNumber(req.PhoneNumber)
+	v.merge("phone_number", err)
 
-	// Validate PLMN format if provided
+	// Validate PLMN format if provided (and registry membership in strict mode)
 	if req.PLMN != nil {
-		if err := ValidatePLMN(req.PLMN); err != nil {
-			return err
+		registry := s.client.plmnRegistry()
+		validate := ValidatePLMN
+		if s.client.config.StrictPLMNValidation {
+			validate = registry.ValidateStrict
+		}
+		v.merge("plmn", validate(req.PLMN))
+		if info, ok := registry.Lookup(req.PLMN.MCC, req.PLMN.MNC); ok {
+			s.client.logger.Debug("Resolved PLMN carrier",
+				Field{"mcc", req.PLMN.MCC},
+				Field{"mnc", req.PLMN.MNC},
+				Field{"carrier", info.Name()},
+				Field{"country", info.CountryISO},
+			)
 		}
 	}
 
 	// Validate consent data if provided
-	if req.ConsentData != nil {
-		if err := ValidateConsentData(req.ConsentData); err != nil {
-			return err
-		}
-	}
+	checkConsentData(v, "consent_data", req.ConsentData)
 
-	return nil
+	if err := v.Err(); err != nil {
+		return "", err
+	}
+	return phoneNumber, nil
 }
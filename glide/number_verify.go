@@ -18,20 +18,21 @@ func newNumberVerifyService(client *Client) NumberVerifyService {
 }
 
 // Verify checks if a phone number belongs to the user
-func (s *numberVerifyService) Verify(ctx context.Context, req *NumberVerifyRequest) (*NumberVerifyResponse, error) {
+func (s *numberVerifyService) Verify(ctx context.Context, req *NumberVerifyRequest, opts ...CallOption) (*NumberVerifyResponse, error) {
 	// Validate request
 	if req.PhoneNumber == "" {
 		return nil, NewError(ErrCodeMissingParameters, "Phone number is required")
 	}
 
-	// Validate phone number format
-	if err := ValidatePhoneNumber(req.PhoneNumber); err != nil {
+	// Validate phone number format (normalizing to E.164 if enabled)
+	phoneNumber, err := s.client.normalizePhoneNumber(req.PhoneNumber)
+	if err != nil {
 		return nil, err
 	}
 
 	// Build API request
 	apiReq := map[string]interface{}{
-		"phone_number": req.PhoneNumber,
+		"phone_number": phoneNumber,
 	}
 
 	// Add code if provided (for code-based verification)
@@ -40,7 +41,7 @@ func (s *numberVerifyService) Verify(ctx context.Context, req *NumberVerifyReque
 	}
 
 	// Make API call
-	respData, err := s.client.doRequest(ctx, "POST", "/number-verify/verify", apiReq)
+	respData, err := s.client.doRequest(ctx, "POST", "/number-verify/verify", apiReq, opts...)
 	if err != nil {
 		return nil, err
 	}
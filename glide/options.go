@@ -1,6 +1,7 @@
 package glide
 
 import (
+	"io"
 	"net/http"
 	"time"
 )
@@ -8,10 +9,40 @@ import (
 // Option is a functional option for configuring the client
 type Option func(*Config)
 
-// WithAPIKey sets the API key for authentication
+// WithConfig replaces the configuration with a copy of cfg, e.g. one returned by LoadConfig
+// Options before it are overwritten, options after it still apply
+func WithConfig(cfg *Config) Option {
+	return func(c *Config) {
+		*c = *cfg
+		if cfg.CircuitBreaker != nil {
+			breaker := *cfg.CircuitBreaker
+			c.CircuitBreaker = &breaker
+		}
+		if cfg.Endpoints != nil {
+			c.Endpoints = append([]Endpoint(nil), cfg.Endpoints...)
+		}
+		if cfg.OperationRateLimits != nil {
+			c.OperationRateLimits = make(map[string]RateLimit, len(cfg.OperationRateLimits))
+			for operation, limit := range cfg.OperationRateLimits {
+				c.OperationRateLimits[operation] = limit
+			}
+		}
+	}
+}
+
+// WithAPIKey sets the API key for authentication, replacing any credential provider
 func WithAPIKey(key string) Option {
 	return func(c *Config) {
 		c.APIKey = key
+		c.Credentials = nil
+	}
+}
+
+// WithCredentialProvider reads the API key from p before every request
+// A call rejected with 401 is retried once if the provider then returns a different key
+func WithCredentialProvider(p CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = p
 	}
 }
 
@@ -22,6 +53,38 @@ func WithBaseURL(url string) Option {
 	}
 }
 
+// WithEndpoints replaces BaseURL with regional endpoints tried in the order chosen by policy
+// A retried attempt moves to the next endpoint when the previous one failed with a transport
+// error or a 5xx response, without waiting for the retry delay
+func WithEndpoints(policy EndpointPolicy, endpoints ...Endpoint) Option {
+	return func(c *Config) {
+		c.EndpointPolicy = policy
+		c.Endpoints = endpoints
+	}
+}
+
+// WithEndpointCooldown sets how long a failed endpoint is skipped while others are healthy
+func WithEndpointCooldown(cooldown time.Duration) Option {
+	return func(c *Config) {
+		c.EndpointCooldown = cooldown
+	}
+}
+
+// WithHealthCheckPath sets the read-only GET endpoint probed by Ping and Diagnose
+// It must answer 2xx for an accepted API key and 401 or 403 for a rejected one
+func WithHealthCheckPath(path string) Option {
+	return func(c *Config) {
+		c.HealthCheckPath = path
+	}
+}
+
+// WithDataResidency pins calls to the endpoints that list country, e.g. "DE"
+func WithDataResidency(country string) Option {
+	return func(c *Config) {
+		c.DataResidency = country
+	}
+}
+
 // WithTimeout sets the request timeout
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Config) {
@@ -44,6 +107,14 @@ func WithRetry(count int, delay time.Duration) Option {
 	}
 }
 
+// WithAutoIdempotencyKeys enables or disables generating an Idempotency-Key per call
+// Without a key, session-creating and billed operations are not retried
+func WithAutoIdempotencyKeys(enabled bool) Option {
+	return func(c *Config) {
+		c.AutoIdempotencyKeys = enabled
+	}
+}
+
 // WithRateLimit enables rate limiting with the specified rate
 func WithRateLimit(rate int, period time.Duration) Option {
 	return func(c *Config) {
@@ -53,6 +124,49 @@ func WithRateLimit(rate int, period time.Duration) Option {
 	}
 }
 
+// WithOperationRateLimit limits a single operation, in addition to any global limit
+// operation is an endpoint path or prefix, e.g. "/kyc" for all KYC endpoints
+func WithOperationRateLimit(operation string, rate int, period time.Duration) Option {
+	return func(c *Config) {
+		if c.OperationRateLimits == nil {
+			c.OperationRateLimits = make(map[string]RateLimit)
+		}
+		c.RateLimitEnabled = true
+		c.OperationRateLimits[operation] = RateLimit{Rate: rate, Period: period}
+	}
+}
+
+// WithRateLimiter sets a custom rate limiter, e.g. one shared across processes
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Config) {
+		c.RateLimitEnabled = true
+		c.RateLimiter = limiter
+	}
+}
+
+// WithQuotaPacing enables or disables slowing down based on server rate limit headers (default: enabled)
+// Waits longer than the call timeout fail fast with RATE_LIMIT_EXCEEDED and a retry_after detail
+func WithQuotaPacing(enabled bool) Option {
+	return func(c *Config) {
+		c.QuotaPacing = enabled
+	}
+}
+
+// WithQuotaWarningThreshold sets the fraction of the server quota (0-1) below which a warning is logged
+func WithQuotaWarningThreshold(threshold float64) Option {
+	return func(c *Config) {
+		c.QuotaWarningThreshold = threshold
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker per operation
+// Zero values in cfg use the defaults
+func WithCircuitBreaker(cfg CircuitBreakerConfig) Option {
+	return func(c *Config) {
+		c.CircuitBreaker = &cfg
+	}
+}
+
 // WithNoRateLimit explicitly disables rate limiting
 func WithNoRateLimit() Option {
 	return func(c *Config) {
@@ -60,6 +174,44 @@ func WithNoRateLimit() Option {
 	}
 }
 
+// WithPhoneNormalization converts formatted phone numbers to E.164 instead of rejecting them
+// defaultRegion (ISO 3166-1 alpha-2) is used for numbers without a country calling code
+func WithPhoneNormalization(defaultRegion string) Option {
+	return func(c *Config) {
+		c.NormalizePhoneNumbers = true
+		c.DefaultRegion = defaultRegion
+	}
+}
+
+// WithStrictPLMNValidation rejects PLMNs that are not in the MCC/MNC registry
+func WithStrictPLMNValidation(strict bool) Option {
+	return func(c *Config) {
+		c.StrictPLMNValidation = strict
+	}
+}
+
+// WithPLMNRegistry uses a custom MCC/MNC registry instead of the embedded dataset
+func WithPLMNRegistry(registry *PLMNRegistry) Option {
+	return func(c *Config) {
+		c.PLMNRegistry = registry
+	}
+}
+
+// WithKYCNormalization enables or disables normalization of KYC match input
+func WithKYCNormalization(enabled bool) Option {
+	return func(c *Config) {
+		c.KYCNormalize = enabled
+	}
+}
+
+// WithKYCHashing sends SHA-256 hashed KYC values where the API supports it
+// Values are normalized before hashing so they match the operator's hashes
+func WithKYCHashing(enabled bool) Option {
+	return func(c *Config) {
+		c.KYCHashing = enabled
+	}
+}
+
 // WithDebug enables debug logging with debug level
 func WithDebug(debug bool) Option {
 	return func(c *Config) {
@@ -97,3 +249,10 @@ func WithLogFormat(format LogFormat) Option {
 		c.LogFormat = format
 	}
 }
+
+// WithLogOutput sends the default logger's output to w instead of stdout, e.g. os.Stderr
+func WithLogOutput(w io.Writer) Option {
+	return func(c *Config) {
+		c.LogOutput = w
+	}
+}
@@ -0,0 +1,224 @@
+package glide
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/phonenumber"
+)
+
+//go:embed data/plmn.csv
+var embeddedPLMNData string
+
+// PLMNInfo describes a mobile network identified by its MCC/MNC
+type PLMNInfo struct {
+	MCC         string `json:"mcc"`
+	MNC         string `json:"mnc"`
+	CountryISO  string `json:"country_iso"`  // ISO 3166-1 alpha-2
+	CountryName string `json:"country_name"` // English short name
+	Brand       string `json:"brand"`        // Consumer brand, e.g. "T-Mobile US"
+	Operator    string `json:"operator"`     // Legal operator name
+}
+
+// Name returns the brand, falling back to the operator name
+func (p PLMNInfo) Name() string {
+	if p.Brand != "" {
+		return p.Brand
+	}
+	return p.Operator
+}
+
+// PLMNRegistry is an MCC/MNC dataset used for carrier lookup and strict PLMN validation
+// Registries are safe for concurrent use; pass one to a client with WithPLMNRegistry
+type PLMNRegistry struct {
+	mu      sync.RWMutex
+	entries map[string]PLMNInfo // Keyed by MCC+"-"+MNC
+}
+
+var (
+	defaultPLMNOnce     sync.Once
+	defaultPLMNRegistry *PLMNRegistry
+)
+
+// defaultPLMNs returns the registry of the embedded dataset, parsed on first use
+// It is not exported, so it is never modified
+// The dataset is checked by the test suite; a parse error leaves the registry empty
+func defaultPLMNs() *PLMNRegistry {
+	defaultPLMNOnce.Do(func() {
+		entries, err := parsePLMNData(strings.NewReader(embeddedPLMNData))
+		if err != nil {
+			entries = map[string]PLMNInfo{}
+		}
+		defaultPLMNRegistry = &PLMNRegistry{entries: entries}
+	})
+	return defaultPLMNRegistry
+}
+
+// NewPLMNRegistry creates a registry holding a copy of the embedded dataset
+func NewPLMNRegistry() *PLMNRegistry {
+	embedded := defaultPLMNs().entries
+	entries := make(map[string]PLMNInfo, len(embedded))
+	for key, info := range embedded {
+		entries[key] = info
+	}
+	return &PLMNRegistry{entries: entries}
+}
+
+// LoadPLMNRegistry creates a registry from CSV data (mcc,mnc,iso,brand,operator with header)
+// Use this to ship a newer dataset without upgrading the SDK
+func LoadPLMNRegistry(r io.Reader) (*PLMNRegistry, error) {
+	entries, err := parsePLMNData(r)
+	if err != nil {
+		return nil, NewError(ErrCodeValidationError, "Invalid PLMN registry data: "+err.Error())
+	}
+	return &PLMNRegistry{entries: entries}, nil
+}
+
+// plmnKey builds the registry key for an MCC/MNC pair
+func plmnKey(mcc, mnc string) string {
+	return mcc + "-" + mnc
+}
+
+// parsePLMNData reads CSV rows of mcc,mnc,iso,brand,operator with a header line
+func parsePLMNData(r io.Reader) (map[string]PLMNInfo, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 5
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no records")
+	}
+
+	entries := make(map[string]PLMNInfo, len(records)-1)
+	for i, record := range records[1:] { // Skip header
+		info := PLMNInfo{
+			MCC:        strings.TrimSpace(record[0]),
+			MNC:        strings.TrimSpace(record[1]),
+			CountryISO: strings.ToUpper(strings.TrimSpace(record[2])),
+			Brand:      strings.TrimSpace(record[3]),
+			Operator:   strings.TrimSpace(record[4]),
+		}
+		if err := ValidatePLMN(&PLMN{MCC: info.MCC, MNC: info.MNC}); err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+2, err)
+		}
+		info.CountryName = countryName(info.CountryISO)
+		entries[plmnKey(info.MCC, info.MNC)] = info
+	}
+	return entries, nil
+}
+
+// Register adds or replaces a single registry entry
+func (r *PLMNRegistry) Register(info PLMNInfo) error {
+	if err := ValidatePLMN(&PLMN{MCC: info.MCC, MNC: info.MNC}); err != nil {
+		return err
+	}
+	if info.CountryName == "" {
+		info.CountryName = countryName(info.CountryISO)
+	}
+
+	r.mu.Lock()
+	r.entries[plmnKey(info.MCC, info.MNC)] = info
+	r.mu.Unlock()
+	return nil
+}
+
+// Lookup returns carrier information for an MCC/MNC pair
+func (r *PLMNRegistry) Lookup(mcc, mnc string) (PLMNInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.entries[plmnKey(mcc, mnc)]
+	return info, ok
+}
+
+// ForCountry returns all known networks for an ISO 3166-1 country code or name
+func (r *PLMNRegistry) ForCountry(country string) []PLMNInfo {
+	iso, ok := NormalizeCountryCode(country)
+	if !ok {
+		return nil
+	}
+
+	r.mu.RLock()
+	var result []PLMNInfo
+	for _, info := range r.entries {
+		if info.CountryISO == iso {
+			result = append(result, info)
+		}
+	}
+	r.mu.RUnlock()
+
+	sortPLMNs(result)
+	return result
+}
+
+// ForPhoneNumber infers the candidate networks for a phone number from its country
+func (r *PLMNRegistry) ForPhoneNumber(phoneNumber string) []PLMNInfo {
+	parsed, err := phonenumber.Parse(phoneNumber, "")
+	if err != nil {
+		return nil
+	}
+	return r.ForCountry(parsed.Region)
+}
+
+// ValidateStrict validates the PLMN format and checks it exists in the registry
+func (r *PLMNRegistry) ValidateStrict(plmn *PLMN) error {
+	if plmn == nil {
+		return nil
+	}
+	if err := ValidatePLMN(plmn); err != nil {
+		return err
+	}
+	if _, ok := r.Lookup(plmn.MCC, plmn.MNC); !ok {
+		v := &ValidationError{}
+		v.Add("plmn", RuleRegistry, fmt.Sprintf("Unknown PLMN %s/%s", plmn.MCC, plmn.MNC))
+		return v.Err()
+	}
+	return nil
+}
+
+// plmnRegistry returns the client's registry, the embedded dataset unless one was configured
+func (c *Client) plmnRegistry() *PLMNRegistry {
+	if c.config.PLMNRegistry != nil {
+		return c.config.PLMNRegistry
+	}
+	return defaultPLMNs()
+}
+
+// LookupPLMN returns carrier information for an MCC/MNC pair from the embedded dataset
+func LookupPLMN(mcc, mnc string) (PLMNInfo, bool) {
+	return defaultPLMNs().Lookup(mcc, mnc)
+}
+
+// PLMNsForCountry returns all networks of the embedded dataset for an ISO 3166-1 country code or name
+func PLMNsForCountry(country string) []PLMNInfo {
+	return defaultPLMNs().ForCountry(country)
+}
+
+// PLMNsForPhoneNumber infers the candidate networks for a phone number from the embedded dataset
+func PLMNsForPhoneNumber(phoneNumber string) []PLMNInfo {
+	return defaultPLMNs().ForPhoneNumber(phoneNumber)
+}
+
+// ValidatePLMNStrict validates the PLMN format and checks it exists in the embedded dataset
+func ValidatePLMNStrict(plmn *PLMN) error {
+	return defaultPLMNs().ValidateStrict(plmn)
+}
+
+// sortPLMNs orders networks by MCC then MNC
+func sortPLMNs(list []PLMNInfo) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].MCC != list[j].MCC {
+			return list[i].MCC < list[j].MCC
+		}
+		return list[i].MNC < list[j].MNC
+	})
+}
@@ -0,0 +1,301 @@
+package glide
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultQuotaWarningThreshold is the fraction of the server quota below which a warning is logged
+const DefaultQuotaWarningThreshold = 0.1
+
+// QuotaState is the server-side quota reported in rate limit response headers
+type QuotaState struct {
+	Limit      int       // Requests allowed per window, -1 if unknown
+	Remaining  int       // Requests left in the current window, -1 if unknown
+	Reset      time.Time // When the window resets, zero if unknown
+	RetryAfter time.Time // Server asked to wait until this time (Retry-After), zero if not set
+	UpdatedAt  time.Time // Time of the last response carrying quota headers, zero if none yet
+}
+
+// Known reports whether any quota information has been received
+func (q QuotaState) Known() bool {
+	return !q.UpdatedAt.IsZero()
+}
+
+// quotaTracker records the latest quota state per operation and paces requests accordingly
+// Operations are tracked separately so an exhausted API does not hold back the others
+type quotaTracker struct {
+	threshold float64
+	logger    Logger
+
+	mu      sync.Mutex
+	windows map[string]*quotaWindow
+	latest  *quotaWindow // Window updated by the most recent response
+}
+
+// quotaWindow is the quota state of one operation
+type quotaWindow struct {
+	state       QuotaState
+	next        time.Time // Earliest start of the next paced request
+	warnedReset time.Time // Window for which a low-quota warning was already logged
+}
+
+// newQuotaTracker creates a tracker with an unknown quota
+func newQuotaTracker(threshold float64, logger Logger) *quotaTracker {
+	if threshold <= 0 || threshold >= 1 {
+		threshold = DefaultQuotaWarningThreshold
+	}
+	return &quotaTracker{
+		threshold: threshold,
+		logger:    logger,
+		windows:   make(map[string]*quotaWindow),
+	}
+}
+
+// unknownQuota is the state before any quota headers were received
+var unknownQuota = QuotaState{Limit: -1, Remaining: -1}
+
+// snapshot returns the quota state of the most recent response carrying quota headers
+func (q *quotaTracker) snapshot() QuotaState {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.latest == nil {
+		return unknownQuota
+	}
+	return q.latest.state
+}
+
+// snapshotFor returns the quota state of an operation
+func (q *quotaTracker) snapshotFor(operation string) QuotaState {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if w, ok := q.windows[operation]; ok {
+		return w.state
+	}
+	return unknownQuota
+}
+
+// update parses X-RateLimit-*, RateLimit-* and Retry-After headers from a response to an operation
+func (q *quotaTracker) update(operation string, header http.Header, now time.Time) {
+	limit, hasLimit := headerInt(header, "X-RateLimit-Limit", "RateLimit-Limit")
+	remaining, hasRemaining := headerInt(header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	reset, hasReset := parseResetHeader(header, now)
+	retryAfter, hasRetryAfter := parseRetryAfter(header.Get("Retry-After"), now)
+	if !hasLimit && !hasRemaining && !hasReset && !hasRetryAfter {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	w, ok := q.windows[operation]
+	if !ok {
+		w = &quotaWindow{state: unknownQuota}
+		q.windows[operation] = w
+	}
+	q.latest = w
+
+	if hasLimit {
+		w.state.Limit = limit
+	}
+	if hasRemaining {
+		w.state.Remaining = remaining
+	}
+	if hasReset {
+		w.state.Reset = reset
+	}
+	if hasRetryAfter {
+		w.state.RetryAfter = retryAfter
+	}
+	w.state.UpdatedAt = now
+
+	if w.low(q.threshold) && !w.state.Reset.Equal(w.warnedReset) {
+		w.warnedReset = w.state.Reset
+		q.logger.Warn("API quota running low",
+			Field{"operation", operation},
+			Field{"remaining", w.state.Remaining},
+			Field{"limit", w.state.Limit},
+			Field{"reset", w.state.Reset.Format(time.RFC3339)},
+		)
+	}
+}
+
+// low reports whether the remaining quota is below the warning threshold
+func (w *quotaWindow) low(threshold float64) bool {
+	return w.state.Limit > 0 && w.state.Remaining >= 0 &&
+		float64(w.state.Remaining) <= threshold*float64(w.state.Limit)
+}
+
+// delay returns how long the next request to an operation should wait to stay within the server quota
+// A paced slot is only claimed when the delay is at most maxWait, so rejected calls do not push back others
+func (q *quotaTracker) delay(operation string, now time.Time, maxWait time.Duration) time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	w, ok := q.windows[operation]
+	if !ok {
+		return 0
+	}
+
+	// An explicit Retry-After always wins
+	if now.Before(w.state.RetryAfter) {
+		return w.state.RetryAfter.Sub(now)
+	}
+	if !now.Before(w.state.Reset) {
+		return 0
+	}
+
+	// Quota exhausted: wait for the window to reset
+	if w.state.Remaining == 0 {
+		return w.state.Reset.Sub(now)
+	}
+	if !w.low(q.threshold) {
+		return 0
+	}
+
+	// Quota low: spread the remaining requests over the rest of the window
+	interval := w.state.Reset.Sub(now) / time.Duration(w.state.Remaining+1)
+	start := now
+	if w.next.After(now) {
+		start = w.next
+	}
+	if start.Sub(now) <= maxWait {
+		w.next = start.Add(interval)
+	}
+	return start.Sub(now)
+}
+
+// backoff returns the time left until an operation's Retry-After, if any
+func (q *quotaTracker) backoff(operation string, now time.Time) time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if w, ok := q.windows[operation]; ok && now.Before(w.state.RetryAfter) {
+		return w.state.RetryAfter.Sub(now)
+	}
+	return 0
+}
+
+// quotaExceededError is returned when pacing would hold a request longer than allowed
+type quotaExceededError struct {
+	retryAfter time.Duration
+}
+
+func (e *quotaExceededError) Error() string {
+	return "server quota exhausted, retry after " + e.retryAfter.Round(time.Second).String()
+}
+
+// maxWaitKey is the context key of the longest pacing delay allowed for a call
+type maxWaitKey struct{}
+
+// withMaxWait returns a context that caps the pacing delay of a call, e.g. at its timeout
+func withMaxWait(ctx context.Context, maxWait time.Duration) context.Context {
+	return context.WithValue(ctx, maxWaitKey{}, maxWait)
+}
+
+// quotaRateLimiter slows requests down based on the server quota before delegating to the next limiter
+type quotaRateLimiter struct {
+	quota   *quotaTracker
+	maxWait time.Duration // Longest pacing delay unless the context sets one, usually Config.Timeout
+	next    RateLimiter   // Optional
+}
+
+// Wait blocks until the server quota and the next limiter allow a request
+// It fails fast with a quotaExceededError when the delay exceeds maxWait or the context deadline
+func (l *quotaRateLimiter) Wait(ctx context.Context, operation string) error {
+	now := time.Now()
+	maxWait := l.maxWait
+	if d, ok := ctx.Value(maxWaitKey{}).(time.Duration); ok {
+		maxWait = d
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(now) < maxWait {
+		maxWait = deadline.Sub(now)
+	}
+	if delay := l.quota.delay(operation, now, maxWait); delay > 0 {
+		if delay > maxWait {
+			return &quotaExceededError{retryAfter: delay}
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+	if l.next != nil {
+		return l.next.Wait(ctx, operation)
+	}
+	return nil
+}
+
+// sleepContext waits for d, failing immediately if the context deadline is earlier
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// headerInt returns the first non-negative integer found in the given headers
+func headerInt(header http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		value := strings.TrimSpace(header.Get(name))
+		if value == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// parseResetHeader reads X-RateLimit-Reset (Unix time or seconds) or RateLimit-Reset (seconds)
+func parseResetHeader(header http.Header, now time.Time) (time.Time, bool) {
+	if n, ok := headerInt(header, "X-RateLimit-Reset"); ok {
+		// Large values are Unix timestamps, small ones are seconds from now
+		if n > 1000000000 {
+			return time.Unix(int64(n), 0), true
+		}
+		return now.Add(time.Duration(n) * time.Second), true
+	}
+	if n, ok := headerInt(header, "RateLimit-Reset"); ok {
+		return now.Add(time.Duration(n) * time.Second), true
+	}
+	return time.Time{}, false
+}
+
+// parseRetryAfter reads a Retry-After value in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date, true
+	}
+	return time.Time{}, false
+}
+
+// Quota returns the quota reported for the operation of the last response carrying quota headers
+// Each operation has its own quota, use QuotaFor to read a specific one
+func (c *Client) Quota() QuotaState {
+	return c.quota.snapshot()
+}
+
+// QuotaFor returns the latest server quota reported for an operation, e.g. "/kyc-match/match"
+func (c *Client) QuotaFor(operation string) QuotaState {
+	return c.quota.snapshotFor(operation)
+}
@@ -0,0 +1,289 @@
+package glide
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter limits outgoing API requests
+// Implementations must be safe for concurrent use
+type RateLimiter interface {
+	// Wait blocks until a request for the operation may proceed
+	// operation is the endpoint path, e.g. "/kyc-match/match"
+	Wait(ctx context.Context, operation string) error
+}
+
+// RateLimit allows Rate requests per Period, with bursts of up to Rate requests
+type RateLimit struct {
+	Rate   int
+	Period time.Duration
+}
+
+// interval returns the time between two tokens
+func (l RateLimit) interval() time.Duration {
+	return l.Period / time.Duration(l.Rate)
+}
+
+// IntervalMicros returns the time between two tokens in whole microseconds, at least 1
+// This is ARGV[2] of TokenBucketScript
+func (l RateLimit) IntervalMicros() int64 {
+	if micros := l.interval().Microseconds(); micros > 0 {
+		return micros
+	}
+	return 1
+}
+
+// valid reports whether the limit can be enforced
+func (l RateLimit) valid() bool {
+	return l.Rate > 0 && l.Period > 0
+}
+
+// RateLimits configures a global limit and per-operation limits
+// Operation keys are endpoint paths or path prefixes, e.g. "/kyc" covers all KYC endpoints
+// The longest matching key applies, in addition to the global limit
+type RateLimits struct {
+	Global     *RateLimit           // Limit across all operations (optional)
+	Operations map[string]RateLimit // Limits per operation (optional)
+}
+
+// operationKeys returns the operation keys ordered by decreasing length
+func (l RateLimits) operationKeys() []string {
+	keys := make([]string, 0, len(l.Operations))
+	for key, limit := range l.Operations {
+		if limit.valid() {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// matchOperation returns the most specific key for an operation
+func matchOperation(keys []string, operation string) (string, bool) {
+	for _, key := range keys {
+		if strings.HasPrefix(operation, key) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// LocalRateLimiter is the default in-process RateLimiter based on x/time/rate
+type LocalRateLimiter struct {
+	global     *rate.Limiter
+	keys       []string
+	operations map[string]*rate.Limiter
+}
+
+// NewLocalRateLimiter creates an in-process rate limiter
+func NewLocalRateLimiter(limits RateLimits) *LocalRateLimiter {
+	l := &LocalRateLimiter{
+		keys:       limits.operationKeys(),
+		operations: make(map[string]*rate.Limiter),
+	}
+	if limits.Global != nil && limits.Global.valid() {
+		l.global = newTokenBucket(*limits.Global)
+	}
+	for _, key := range l.keys {
+		l.operations[key] = newTokenBucket(limits.Operations[key])
+	}
+	return l
+}
+
+// newTokenBucket creates an x/time/rate limiter for a RateLimit
+func newTokenBucket(limit RateLimit) *rate.Limiter {
+	return rate.NewLimiter(rate.Every(limit.interval()), limit.Rate)
+}
+
+// errRateLimitDeadline is returned when the wait for a token would outlast the context deadline
+var errRateLimitDeadline = errors.New("rate limit wait would exceed the context deadline")
+
+// Wait blocks until both the operation and the global limit allow a request
+// Tokens are reserved from both buckets together and returned if the wait is abandoned
+func (l *LocalRateLimiter) Wait(ctx context.Context, operation string) error {
+	limiters := make([]*rate.Limiter, 0, 2)
+	if key, ok := matchOperation(l.keys, operation); ok {
+		limiters = append(limiters, l.operations[key])
+	}
+	if l.global != nil {
+		limiters = append(limiters, l.global)
+	}
+	if len(limiters) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, len(limiters))
+	cancel := func() {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+	}
+	var delay time.Duration
+	for _, limiter := range limiters {
+		r := limiter.ReserveN(now, 1)
+		if !r.OK() {
+			cancel()
+			return errRateLimitDeadline
+		}
+		reservations = append(reservations, r)
+		if d := r.DelayFrom(now); d > delay {
+			delay = d
+		}
+	}
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		cancel()
+		return errRateLimitDeadline
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	}
+}
+
+// TokenBucketStore is an adapter for a token bucket shared between processes, e.g. in Redis
+// Take must refill and take a token atomically; with Redis, run TokenBucketScript via EVALSHA
+type TokenBucketStore interface {
+	// Take tries to take one token from the bucket identified by key
+	// When no token is available it returns false and the time until the next token
+	Take(ctx context.Context, key string, limit RateLimit) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// TokenBucketRefunder is optionally implemented by a TokenBucketStore to return a token
+// It is used when a call took an operation token but could not get a global one.
+// With Redis, run TokenBucketRefundScript via EVALSHA.
+type TokenBucketRefunder interface {
+	Refund(ctx context.Context, key string, limit RateLimit) error
+}
+
+// TokenBucketScript is a Redis Lua script implementing TokenBucketStore.Take
+// KEYS[1] is the bucket key, ARGV[1] the capacity (RateLimit.Rate) and
+// ARGV[2] the microseconds between tokens (RateLimit.IntervalMicros).
+// It returns {allowed (0 or 1), retry after in milliseconds}.
+// The Redis server clock is used so replicas with clock skew share one bucket.
+const TokenBucketScript = `
+local capacity = tonumber(ARGV[1])
+local interval = math.max(1, tonumber(ARGV[2]))
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1]) or capacity
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + (now - ts) / interval)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) * interval / 1000)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity * interval / 1000) + 1000)
+return {allowed, wait}
+`
+
+// TokenBucketRefundScript is a Redis Lua script implementing TokenBucketRefunder.Refund
+// KEYS[1] is the bucket key and ARGV[1] the capacity (RateLimit.Rate).
+const TokenBucketRefundScript = `
+local capacity = tonumber(ARGV[1])
+local tokens = tonumber(redis.call('HGET', KEYS[1], 'tokens'))
+if tokens then
+  redis.call('HSET', KEYS[1], 'tokens', tostring(math.min(capacity, tokens + 1)))
+end
+return 1
+`
+
+// DefaultRateLimitKeyPrefix is the default key prefix for shared token buckets
+const DefaultRateLimitKeyPrefix = "glide:ratelimit:"
+
+// DistributedRateLimiter enforces limits shared by all processes using the same store
+type DistributedRateLimiter struct {
+	store     TokenBucketStore
+	keyPrefix string
+	limits    RateLimits
+	keys      []string
+}
+
+// NewDistributedRateLimiter creates a rate limiter backed by a shared token bucket store
+// Processes sharing a quota must use the same keyPrefix (default: DefaultRateLimitKeyPrefix)
+func NewDistributedRateLimiter(store TokenBucketStore, keyPrefix string, limits RateLimits) *DistributedRateLimiter {
+	if keyPrefix == "" {
+		keyPrefix = DefaultRateLimitKeyPrefix
+	}
+	return &DistributedRateLimiter{
+		store:     store,
+		keyPrefix: keyPrefix,
+		limits:    limits,
+		keys:      limits.operationKeys(),
+	}
+}
+
+// Wait blocks until both the operation and the global bucket have a token
+// The operation token is refunded when the global one cannot be taken and the store
+// implements TokenBucketRefunder
+func (l *DistributedRateLimiter) Wait(ctx context.Context, operation string) error {
+	key, limited := matchOperation(l.keys, operation)
+	if limited {
+		if err := l.take(ctx, l.keyPrefix+"op:"+key, l.limits.Operations[key]); err != nil {
+			return err
+		}
+	}
+	if l.limits.Global == nil || !l.limits.Global.valid() {
+		return nil
+	}
+	err := l.take(ctx, l.keyPrefix+"global", *l.limits.Global)
+	if err != nil && limited {
+		if refunder, ok := l.store.(TokenBucketRefunder); ok {
+			// The caller's context may be done already
+			refunder.Refund(context.WithoutCancel(ctx), l.keyPrefix+"op:"+key, l.limits.Operations[key])
+		}
+	}
+	return err
+}
+
+// take polls the store until a token is available or the context is done
+func (l *DistributedRateLimiter) take(ctx context.Context, key string, limit RateLimit) error {
+	for {
+		allowed, retryAfter, err := l.store.Take(ctx, key, limit)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			return nil
+		}
+		if retryAfter <= 0 {
+			retryAfter = limit.interval()
+		}
+		if deadline, ok := ctx.Deadline(); ok && deadline.Before(time.Now().Add(retryAfter)) {
+			return errRateLimitDeadline
+		}
+
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
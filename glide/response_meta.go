@@ -0,0 +1,47 @@
+package glide
+
+import (
+	"net/http"
+	"time"
+)
+
+// requestIDHeader is the response header carrying the Glide request ID
+const requestIDHeader = "X-Request-ID"
+
+// ResponseMeta describes the HTTP exchange behind an API call
+// Request it with the WithResponseMeta call option
+type ResponseMeta struct {
+	StatusCode     int           // Status of the last attempt, 0 if no response was received
+	Header         http.Header   // Headers of the last response
+	RequestID      string        // Glide request ID, for support tickets
+	IdempotencyKey string        // Idempotency-Key sent with the call
+	Attempts       int           // Number of HTTP attempts made
+	Latency        time.Duration // Total time including rate limiting and retries
+	RateLimitWait  time.Duration // Time spent waiting for client-side rate limits
+	Endpoint       string        // Base URL that served the last attempt
+	Region         string        // Region of that endpoint, if configured
+}
+
+// fillMeta copies the collected call metadata into the caller's ResponseMeta
+func (o *callOptions) fillMeta(start time.Time, err error) {
+	if o.meta == nil {
+		return
+	}
+
+	*o.meta = ResponseMeta{
+		StatusCode:     o.status,
+		Header:         o.header,
+		RequestID:      o.header.Get(requestIDHeader),
+		IdempotencyKey: o.idempotencyKey,
+		Attempts:       o.attempts,
+		Latency:        time.Since(start),
+		RateLimitWait:  o.rateLimitWait,
+	}
+	if o.endpoint != nil {
+		o.meta.Endpoint = o.endpoint.URL
+		o.meta.Region = o.endpoint.Region
+	}
+	if glideErr, ok := err.(*Error); ok && o.meta.RequestID == "" {
+		o.meta.RequestID = glideErr.RequestID
+	}
+}
@@ -23,32 +23,53 @@ const (
 // MagicAuthService handles SIM-based phone authentication
 type MagicAuthService interface {
 	// Prepare initiates the authentication flow
-	Prepare(ctx context.Context, req *PrepareRequest) (*PrepareResponse, error)
+	Prepare(ctx context.Context, req *PrepareRequest, opts ...CallOption) (*PrepareResponse, error)
 
 	// VerifyPhoneNumber verifies a specific phone number
-	VerifyPhoneNumber(ctx context.Context, req *VerifyPhoneNumberRequest) (*VerifyPhoneNumberResponse, error)
+	VerifyPhoneNumber(ctx context.Context, req *VerifyPhoneNumberRequest, opts ...CallOption) (*VerifyPhoneNumberResponse, error)
 
 	// GetPhoneNumber retrieves the user's phone number
-	GetPhoneNumber(ctx context.Context, req *GetPhoneNumberRequest) (*GetPhoneNumberResponse, error)
+	GetPhoneNumber(ctx context.Context, req *GetPhoneNumberRequest, opts ...CallOption) (*GetPhoneNumberResponse, error)
 }
 
 // SimSwapService handles SIM swap detection
 type SimSwapService interface {
 	// Check verifies if a SIM swap occurred recently
-	Check(ctx context.Context, req *SimSwapCheckRequest) (*SimSwapCheckResponse, error)
+	Check(ctx context.Context, req *SimSwapCheckRequest, opts ...CallOption) (*SimSwapCheckResponse, error)
 
 	// GetLastSwapDate retrieves the last SIM swap date
-	GetLastSwapDate(ctx context.Context, req *SimSwapDateRequest) (*SimSwapDateResponse, error)
+	GetLastSwapDate(ctx context.Context, req *SimSwapDateRequest, opts ...CallOption) (*SimSwapDateResponse, error)
 }
 
 // NumberVerifyService handles number verification
 type NumberVerifyService interface {
 	// Verify checks if a phone number belongs to the user
-	Verify(ctx context.Context, req *NumberVerifyRequest) (*NumberVerifyResponse, error)
+	Verify(ctx context.Context, req *NumberVerifyRequest, opts ...CallOption) (*NumberVerifyResponse, error)
+}
+
+// DeviceStatusService handles device roaming and connectivity status
+type DeviceStatusService interface {
+	// GetRoamingStatus checks if the device is currently roaming
+	GetRoamingStatus(ctx context.Context, req *DeviceStatusRequest, opts ...CallOption) (*RoamingStatusResponse, error)
+
+	// GetConnectivityStatus checks if the device is reachable for data or SMS
+	GetConnectivityStatus(ctx context.Context, req *DeviceStatusRequest, opts ...CallOption) (*ConnectivityStatusResponse, error)
 }
 
 // KYCService handles KYC (Know Your Customer) verification
 type KYCService interface {
 	// Match verifies user identity information
-	Match(ctx context.Context, req *KYCMatchRequest) (*KYCMatchResponse, error)
+	Match(ctx context.Context, req *KYCMatchRequest, opts ...CallOption) (*KYCMatchResponse, error)
+
+	// AgeVerify checks if the subscriber is above an age threshold
+	AgeVerify(ctx context.Context, req *KYCAgeVerifyRequest, opts ...CallOption) (*KYCAgeVerifyResponse, error)
+
+	// FillIn retrieves the subscriber identity held by the operator
+	FillIn(ctx context.Context, req *KYCFillInRequest, opts ...CallOption) (*KYCFillInResponse, error)
+}
+
+// LocationVerificationService handles device location verification
+type LocationVerificationService interface {
+	// Verify checks if the device is within the given area
+	Verify(ctx context.Context, phoneNumber string, area *LocationArea, maxAge int, opts ...CallOption) (*LocationVerifyResponse, error)
 }
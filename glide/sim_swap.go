@@ -18,14 +18,15 @@ func newSimSwapService(client *Client) SimSwapService {
 }
 
 // Check verifies if a SIM swap occurred recently
-func (s *simSwapService) Check(ctx context.Context, req *SimSwapCheckRequest) (*SimSwapCheckResponse, error) {
+func (s *simSwapService) Check(ctx context.Context, req *SimSwapCheckRequest, opts ...CallOption) (*SimSwapCheckResponse, error) {
 	// Validate request
 	if req.PhoneNumber == "" {
 		return nil, NewError(ErrCodeMissingParameters, "Phone number is required")
 	}
 
-	// Validate phone number format
-	if err := ValidatePhoneNumber(req.PhoneNumber); err != nil {
+	// Validate phone number format (normalizing to E.164 if enabled)
+	phoneNumber, err := s.client.normalizePhoneNumber(req.PhoneNumber)
+	if err != nil {
 		return nil, err
 	}
 
@@ -37,12 +38,12 @@ func (s *simSwapService) Check(ctx context.Context, req *SimSwapCheckRequest) (*
 
 	// Build API request
 	apiReq := map[string]interface{}{
-		"phone_number":  req.PhoneNumber,
+		"phone_number":  phoneNumber,
 		"max_age_hours": maxAge,
 	}
 
 	// Make API call
-	respData, err := s.client.doRequest(ctx, "POST", "/sim-swap/check", apiReq)
+	respData, err := s.client.doRequest(ctx, "POST", "/sim-swap/check", apiReq, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -57,24 +58,25 @@ func (s *simSwapService) Check(ctx context.Context, req *SimSwapCheckRequest) (*
 }
 
 // GetLastSwapDate retrieves the last SIM swap date
-func (s *simSwapService) GetLastSwapDate(ctx context.Context, req *SimSwapDateRequest) (*SimSwapDateResponse, error) {
+func (s *simSwapService) GetLastSwapDate(ctx context.Context, req *SimSwapDateRequest, opts ...CallOption) (*SimSwapDateResponse, error) {
 	// Validate request
 	if req.PhoneNumber == "" {
 		return nil, NewError(ErrCodeMissingParameters, "Phone number is required")
 	}
 
-	// Validate phone number format
-	if err := ValidatePhoneNumber(req.PhoneNumber); err != nil {
+	// Validate phone number format (normalizing to E.164 if enabled)
+	phoneNumber, err := s.client.normalizePhoneNumber(req.PhoneNumber)
+	if err != nil {
 		return nil, err
 	}
 
 	// Build API request
 	apiReq := map[string]interface{}{
-		"phone_number": req.PhoneNumber,
+		"phone_number": phoneNumber,
 	}
 
 	// Make API call
-	respData, err := s.client.doRequest(ctx, "POST", "/sim-swap/retrieve-date", apiReq)
+	respData, err := s.client.doRequest(ctx, "POST", "/sim-swap/retrieve-date", apiReq, opts...)
 	if err != nil {
 		return nil, err
 	}
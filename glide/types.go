@@ -132,6 +132,36 @@ type SimSwapDateResponse struct {
 	CheckedAt    time.Time  `json:"checked_at"`
 }
 
+// DeviceStatusRequest requests the roaming or connectivity status of a device
+type DeviceStatusRequest struct {
+	PhoneNumber string `json:"phone_number"`
+}
+
+// RoamingStatusResponse contains the device roaming status
+type RoamingStatusResponse struct {
+	Roaming        bool       `json:"roaming"`
+	CountryCode    int        `json:"country_code,omitempty"` // Mobile country code of the visited network
+	CountryName    []string   `json:"country_name,omitempty"` // ISO 3166 country codes of the visited network
+	LastStatusTime *time.Time `json:"last_status_time,omitempty"`
+	CheckedAt      time.Time  `json:"checked_at"`
+}
+
+// ConnectivityStatus represents how a device is reachable
+type ConnectivityStatus string
+
+const (
+	ConnectivityStatusConnectedData ConnectivityStatus = "CONNECTED_DATA"
+	ConnectivityStatusConnectedSMS  ConnectivityStatus = "CONNECTED_SMS"
+	ConnectivityStatusNotConnected  ConnectivityStatus = "NOT_CONNECTED"
+)
+
+// ConnectivityStatusResponse contains the device connectivity status
+type ConnectivityStatusResponse struct {
+	ConnectivityStatus ConnectivityStatus `json:"connectivity_status"`
+	LastStatusTime     *time.Time         `json:"last_status_time,omitempty"`
+	CheckedAt          time.Time          `json:"checked_at"`
+}
+
 // NumberVerifyRequest verifies phone number ownership
 type NumberVerifyRequest struct {
 	PhoneNumber string `json:"phone_number"`
@@ -146,23 +176,50 @@ type NumberVerifyResponse struct {
 
 // KYCMatchRequest contains user information to verify
 type KYCMatchRequest struct {
-	PhoneNumber string   `json:"phone_number"`
-	Name        string   `json:"name,omitempty"`
-	GivenName   string   `json:"given_name,omitempty"`
-	FamilyName  string   `json:"family_name,omitempty"`
-	BirthDate   string   `json:"birth_date,omitempty"` // Format: YYYY-MM-DD
-	Email       string   `json:"email,omitempty"`
-	Address     *Address `json:"address,omitempty"`
-	IDDocument  string   `json:"id_document,omitempty"`
+	PhoneNumber string `json:"phone_number"`
+
+	// Name fields
+	Name              string `json:"name,omitempty"`
+	GivenName         string `json:"given_name,omitempty"`
+	FamilyName        string `json:"family_name,omitempty"`
+	MiddleNames       string `json:"middle_names,omitempty"`
+	FamilyNameAtBirth string `json:"family_name_at_birth,omitempty"`
+	NameKanaHankaku   string `json:"name_kana_hankaku,omitempty"` // Half-width kana (Japan)
+	NameKanaZenkaku   string `json:"name_kana_zenkaku,omitempty"` // Full-width kana (Japan)
+
+	// Personal details
+	BirthDate   string `json:"birth_date,omitempty"` // Format: YYYY-MM-DD
+	Gender      Gender `json:"gender,omitempty"`
+	Nationality string `json:"nationality,omitempty"` // ISO 3166-1 alpha-2
+	Email       string `json:"email,omitempty"`
+
+	Address *Address `json:"address,omitempty"`
+
+	// Identity document
+	IDDocument           string `json:"id_document,omitempty"`
+	IDDocumentType       string `json:"id_document_type,omitempty"`        // e.g. passport, national_id_card
+	IDDocumentExpiryDate string `json:"id_document_expiry_date,omitempty"` // Format: YYYY-MM-DD
 }
 
+// Gender represents the subscriber gender used for KYC matching
+type Gender string
+
+const (
+	GenderMale   Gender = "MALE"
+	GenderFemale Gender = "FEMALE"
+	GenderOther  Gender = "OTHER"
+)
+
 // Address represents a physical address
 type Address struct {
-	Street     string `json:"street,omitempty"`
-	City       string `json:"city,omitempty"`
-	State      string `json:"state,omitempty"`
-	PostalCode string `json:"postal_code,omitempty"`
-	Country    string `json:"country,omitempty"`
+	Street               string `json:"street,omitempty"` // Street name
+	HouseNumber          string `json:"house_number,omitempty"`
+	HouseNumberExtension string `json:"house_number_extension,omitempty"`
+	City                 string `json:"city,omitempty"`
+	State                string `json:"state,omitempty"`
+	Region               string `json:"region,omitempty"`
+	PostalCode           string `json:"postal_code,omitempty"`
+	Country              string `json:"country,omitempty"`
 }
 
 // KYCMatchResponse contains the KYC verification result
@@ -176,4 +233,122 @@ type KYCMatchResponse struct {
 type MatchResult struct {
 	Matched    bool   `json:"matched"`
 	Confidence string `json:"confidence,omitempty"` // high, medium, low
+
+	// Result is true, false or not_available
+	Result KYCCheckResult `json:"result,omitempty"`
+
+	// MatchScore is the similarity (0-100) for fields that did not match exactly
+	MatchScore *int `json:"match_score,omitempty"`
+}
+
+// AreaType represents the shape of a location verification area
+type AreaType string
+
+const (
+	AreaTypeCircle  AreaType = "CIRCLE"
+	AreaTypePolygon AreaType = "POLYGON"
+)
+
+// Point represents a geographical coordinate
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// LocationArea describes the area the device is expected to be in
+type LocationArea struct {
+	AreaType AreaType `json:"area_type"`
+
+	// Center and Radius (in meters) are used for CIRCLE areas
+	Center *Point `json:"center,omitempty"`
+	Radius int    `json:"radius,omitempty"`
+
+	// Boundary is used for POLYGON areas (3 to 15 points)
+	Boundary []Point `json:"boundary,omitempty"`
+}
+
+// LocationVerificationResult represents the outcome of a location verification
+type LocationVerificationResult string
+
+const (
+	LocationVerificationTrue    LocationVerificationResult = "TRUE"
+	LocationVerificationFalse   LocationVerificationResult = "FALSE"
+	LocationVerificationPartial LocationVerificationResult = "PARTIAL"
+	LocationVerificationUnknown LocationVerificationResult = "UNKNOWN"
+)
+
+// LocationVerifyResponse contains the location verification result
+type LocationVerifyResponse struct {
+	VerificationResult LocationVerificationResult `json:"verification_result"`
+	MatchRate          int                        `json:"match_rate,omitempty"` // Percentage, only for PARTIAL
+	LastLocationTime   *time.Time                 `json:"last_location_time,omitempty"`
+	CheckedAt          time.Time                  `json:"checked_at"`
+}
+
+// KYCCheckResult represents a tri-state KYC check outcome
+type KYCCheckResult string
+
+const (
+	KYCCheckTrue         KYCCheckResult = "true"
+	KYCCheckFalse        KYCCheckResult = "false"
+	KYCCheckNotAvailable KYCCheckResult = "not_available"
+)
+
+// KYCAgeVerifyRequest contains the age threshold and optional identity information
+type KYCAgeVerifyRequest struct {
+	PhoneNumber  string `json:"phone_number"`
+	AgeThreshold int    `json:"age_threshold"` // Years, 1-120
+
+	// Optional identity fields, matched against the subscriber record
+	IDDocument        string `json:"id_document,omitempty"`
+	Name              string `json:"name,omitempty"`
+	GivenName         string `json:"given_name,omitempty"`
+	FamilyName        string `json:"family_name,omitempty"`
+	MiddleNames       string `json:"middle_names,omitempty"`
+	FamilyNameAtBirth string `json:"family_name_at_birth,omitempty"`
+	BirthDate         string `json:"birth_date,omitempty"` // Format: YYYY-MM-DD
+	Email             string `json:"email,omitempty"`
+
+	// Request additional subscriber flags
+	IncludeContentLock     bool `json:"include_content_lock,omitempty"`
+	IncludeParentalControl bool `json:"include_parental_control,omitempty"`
+}
+
+// KYCAgeVerifyResponse contains the age verification result
+type KYCAgeVerifyResponse struct {
+	// AgeCheck indicates if the subscriber is at or above the age threshold
+	AgeCheck KYCCheckResult `json:"age_check"`
+
+	// VerifiedStatus indicates if the operator has verified the subscriber identity
+	VerifiedStatus *bool `json:"verified_status,omitempty"`
+
+	// IdentityMatchScore is the match score (0-100) of the provided identity fields
+	IdentityMatchScore *int `json:"identity_match_score,omitempty"`
+
+	// MatchResults holds the result of each provided identity field, keyed by API field name
+	MatchResults map[string]MatchResult `json:"match_results,omitempty"`
+
+	ContentLock     KYCCheckResult `json:"content_lock,omitempty"`
+	ParentalControl KYCCheckResult `json:"parental_control,omitempty"`
+	CheckedAt       time.Time      `json:"checked_at"`
+}
+
+// IsAboveThreshold returns true if the subscriber passed the age check
+func (r *KYCAgeVerifyResponse) IsAboveThreshold() bool {
+	return r.AgeCheck == KYCCheckTrue
+}
+
+// KYCFillInRequest requests the subscriber identity for form prefilling
+type KYCFillInRequest struct {
+	PhoneNumber string `json:"phone_number"`
+
+	// ConsentData records the user's consent to share their identity (required)
+	ConsentData *ConsentData `json:"consent_data"`
+}
+
+// KYCFillInResponse contains the subscriber identity
+// Identity values are redacted when formatted with %v or logged
+type KYCFillInResponse struct {
+	Identity  KYCIdentity `json:"identity"`
+	CheckedAt time.Time   `json:"checked_at"`
 }
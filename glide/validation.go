@@ -1,114 +1,233 @@
 package glide
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"regexp"
 	"strings"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/phonenumber"
 )
 
 // ValidatePhoneNumber validates E.164 phone number format
 // Returns an error if the phone number is invalid
 func ValidatePhoneNumber(phoneNumber string) error {
+	v := &ValidationError{}
+	checkPhoneNumber(v, "phone_number", phoneNumber)
+	return v.Err()
+}
+
+// checkPhoneNumber records E.164 format violations for a phone number field
+func checkPhoneNumber(v *ValidationError, field, phoneNumber string) {
 	if phoneNumber == "" {
-		return nil // Phone number is optional for GetPhoneNumber
+		return // Phone number is optional for GetPhoneNumber
 	}
 
 	// E.164 format validation - strict, no cleaning
+	before := len(v.Violations)
 	if !strings.HasPrefix(phoneNumber, "+") {
-		return NewError(ErrCodeValidationError, "Phone number must be in E.164 format (start with +)")
+		v.Add(field, RuleFormat, "Phone number must be in E.164 format (start with +)")
 	}
 
 	if len(phoneNumber) < 8 {
-		return NewError(ErrCodeValidationError, "Phone number too short for E.164 format (minimum 8 characters including +)")
+		v.Add(field, RuleMinLength, "Phone number too short for E.164 format (minimum 8 characters including +)")
 	}
 
 	if len(phoneNumber) > 16 {
-		return NewError(ErrCodeValidationError, "Phone number too long for E.164 format (maximum 15 digits after +)")
+		v.Add(field, RuleMaxLength, "Phone number too long for E.164 format (maximum 15 digits after +)")
 	}
 
 	// Check for any invalid characters (spaces, dashes, parentheses, etc.)
 	// E.164 format only allows + followed by digits
-	validFormat := regexp.MustCompile(`^\+\d+$`)
-	if !validFormat.MatchString(phoneNumber) {
-		return NewError(ErrCodeValidationError, "Phone number contains invalid characters. E.164 format only allows + followed by digits")
+	validChars := regexp.MustCompile(`^\+?\d*$`)
+	if !validChars.MatchString(phoneNumber) {
+		v.Add(field, RuleFormat, "Phone number contains invalid characters. E.164 format only allows + followed by digits")
+	}
+
+	if len(v.Violations) > before {
+		return
 	}
 
 	// Detailed E.164 regex validation
 	e164Regex := regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
 	if !e164Regex.MatchString(phoneNumber) {
-		return NewError(ErrCodeValidationError, "Invalid E.164 phone number format")
+		v.Add(field, RuleFormat, "Invalid E.164 phone number format")
+	}
+}
+
+// normalizePhoneNumber validates a phone number, converting it to E.164 first
+// when phone normalization is enabled
+func (c *Client) normalizePhoneNumber(phoneNumber string) (string, error) {
+	if phoneNumber == "" || !c.config.NormalizePhoneNumbers {
+		return phoneNumber, ValidatePhoneNumber(phoneNumber)
+	}
+
+	parsed, err := phonenumber.Parse(phoneNumber, c.config.DefaultRegion)
+	if err == nil {
+		return parsed.E164(), nil
+	}
+
+	// International numbers the metadata does not cover (unknown calling codes, or lengths and
+	// ranges missing from the metadata) fall back to the strict E.164 check used without normalization
+	if strings.HasPrefix(strings.TrimSpace(phoneNumber), "+") && isMetadataGap(err) {
+		if digits, stripErr := phonenumber.StripFormatting(phoneNumber); stripErr == nil {
+			e164 := "+" + digits
+			return e164, ValidatePhoneNumber(e164)
+		}
 	}
 
-	return nil
+	v := &ValidationError{}
+	v.Add("phone_number", RuleFormat, "Invalid phone number: "+err.Error())
+	return "", v.Err()
+}
+
+// isMetadataGap reports whether a parse error may come from incomplete numbering plan metadata
+func isMetadataGap(err error) bool {
+	return errors.Is(err, phonenumber.ErrUnknownCountryCode) ||
+		errors.Is(err, phonenumber.ErrInvalidLength) ||
+		errors.Is(err, phonenumber.ErrInvalidNumber)
 }
 
 // ValidatePLMN validates PLMN (MCC/MNC) values
 // Returns an error if the PLMN is invalid
 func ValidatePLMN(plmn *PLMN) error {
+	v := &ValidationError{}
+	checkPLMN(v, "plmn", plmn)
+	return v.Err()
+}
+
+// checkPLMN records MCC/MNC format violations
+func checkPLMN(v *ValidationError, field string, plmn *PLMN) {
 	if plmn == nil {
-		return nil // PLMN is optional
+		return // PLMN is optional
 	}
 
 	// MCC validation (3 digits) - no range check for telco labs
 	mccRegex := regexp.MustCompile(`^\d{3}$`)
 	if !mccRegex.MatchString(plmn.MCC) {
-		return NewError(ErrCodeValidationError, "MCC must be exactly 3 digits")
+		v.Add(field+".mcc", RuleFormat, "MCC must be exactly 3 digits")
 	}
 
 	// MNC validation (2 or 3 digits)
 	mncRegex := regexp.MustCompile(`^\d{2,3}$`)
 	if !mncRegex.MatchString(plmn.MNC) {
-		return NewError(ErrCodeValidationError, "MNC must be 2 or 3 digits")
+		v.Add(field+".mnc", RuleFormat, "MNC must be 2 or 3 digits")
 	}
 
 	// No range validation - allowing unofficial MCCs for telco labs
-
-	return nil
 }
 
 // ValidateConsentData validates consent data if provided
 func ValidateConsentData(consent *ConsentData) error {
+	v := &ValidationError{}
+	checkConsentData(v, "consent_data", consent)
+	return v.Err()
+}
+
+// checkConsentData records consent data violations
+func checkConsentData(v *ValidationError, field string, consent *ConsentData) {
 	if consent == nil {
-		return nil
+		return
 	}
 
 	// All fields are required if consent data is provided
 	if consent.ConsentText == "" {
-		return NewError(ErrCodeValidationError, "Consent text is required")
+		v.Add(field+".consent_text", RuleRequired, "Consent text is required")
 	}
 	if consent.PolicyLink == "" {
-		return NewError(ErrCodeValidationError, "Policy link is required")
+		v.Add(field+".policy_link", RuleRequired, "Policy link is required")
+	} else if !strings.HasPrefix(consent.PolicyLink, "http://") && !strings.HasPrefix(consent.PolicyLink, "https://") {
+		// Validate policy link is a valid URL
+		v.Add(field+".policy_link", RuleURL, "Policy link must be a valid URL")
 	}
 	if consent.PolicyText == "" {
-		return NewError(ErrCodeValidationError, "Policy text is required")
-	}
-
-	// Validate policy link is a valid URL
-	if !strings.HasPrefix(consent.PolicyLink, "http://") && !strings.HasPrefix(consent.PolicyLink, "https://") {
-		return NewError(ErrCodeValidationError, "Policy link must be a valid URL")
+		v.Add(field+".policy_text", RuleRequired, "Policy text is required")
 	}
-
-	return nil
 }
 
 // ValidateUseCaseRequirements validates use case and phone number/PLMN combination
 // Returns an error if the requirements are not met
 func ValidateUseCaseRequirements(useCase UseCase, phoneNumber string, plmn *PLMN) error {
+	v := &ValidationError{}
+	checkUseCaseRequirements(v, useCase, phoneNumber, plmn)
+	return v.Err()
+}
+
+// checkUseCaseRequirements records phone number/PLMN violations for a use case
+func checkUseCaseRequirements(v *ValidationError, useCase UseCase, phoneNumber string, plmn *PLMN) {
 	switch useCase {
 	case UseCaseGetPhoneNumber:
 		// GetPhoneNumber: We don't know the phone number, need PLMN
 		if phoneNumber != "" {
-			return NewError(ErrCodeValidationError, "Phone number should not be provided for GetPhoneNumber use case")
+			v.Add("phone_number", RuleForbidden, "Phone number should not be provided for GetPhoneNumber use case")
 		}
 		if plmn == nil {
-			return NewError(ErrCodeMissingParameters, "PLMN (MCC/MNC) is required for GetPhoneNumber use case")
+			v.AddMissing("plmn", "PLMN (MCC/MNC) is required for GetPhoneNumber use case")
 		}
 
 	case UseCaseVerifyPhoneNumber:
 		// VerifyPhoneNumber: Need phone number, PLMN is optional
 		if phoneNumber == "" {
-			return NewError(ErrCodeMissingParameters, "Phone number is required for VerifyPhoneNumber use case")
+			v.AddMissing("phone_number", "Phone number is required for VerifyPhoneNumber use case")
 		}
 	}
+}
+
+// Location verification area limits
+const (
+	minAreaRadius      = 2000   // meters
+	maxAreaRadius      = 200000 // meters
+	minPolygonBoundary = 3
+	maxPolygonBoundary = 15
+)
 
-	return nil
+// ValidateLocationArea validates a location verification area
+// Returns an error if the area is invalid
+func ValidateLocationArea(area *LocationArea) error {
+	v := &ValidationError{}
+	checkLocationArea(v, "area", area)
+	return v.Err()
+}
+
+// checkLocationArea records location area violations
+func checkLocationArea(v *ValidationError, field string, area *LocationArea) {
+	if area == nil {
+		v.AddMissing(field, "Area is required")
+		return
+	}
+
+	switch area.AreaType {
+	case AreaTypeCircle:
+		if area.Center == nil {
+			v.AddMissing(field+".center", "Center is required for CIRCLE area")
+		} else {
+			checkPoint(v, field+".center", *area.Center)
+		}
+		if area.Radius < minAreaRadius || area.Radius > maxAreaRadius {
+			v.Add(field+".radius", RuleRange, fmt.Sprintf("Radius must be between %d and %d meters", minAreaRadius, maxAreaRadius))
+		}
+
+	case AreaTypePolygon:
+		if len(area.Boundary) < minPolygonBoundary || len(area.Boundary) > maxPolygonBoundary {
+			v.Add(field+".boundary", RuleRange, fmt.Sprintf("Polygon boundary must have between %d and %d points", minPolygonBoundary, maxPolygonBoundary))
+		}
+		for i, point := range area.Boundary {
+			checkPoint(v, fmt.Sprintf("%s.boundary[%d]", field, i), point)
+		}
+
+	default:
+		v.Add(field+".area_type", RuleEnum, "Area type must be CIRCLE or POLYGON")
+	}
+}
+
+// checkPoint records coordinate range violations
+// NaN compares false against both bounds, so it is checked explicitly
+func checkPoint(v *ValidationError, field string, point Point) {
+	if math.IsNaN(point.Latitude) || point.Latitude < -90 || point.Latitude > 90 {
+		v.Add(field+".latitude", RuleRange, "Latitude must be between -90 and 90")
+	}
+	if math.IsNaN(point.Longitude) || point.Longitude < -180 || point.Longitude > 180 {
+		v.Add(field+".longitude", RuleRange, "Longitude must be between -180 and 180")
+	}
 }
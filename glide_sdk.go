@@ -29,9 +29,10 @@ import (
 
 // Client and configuration types
 type (
-	Client = glide.Client
-	Config = glide.Config
-	Option = glide.Option
+	Client   = glide.Client
+	Config   = glide.Config
+	Option   = glide.Option
+	Services = glide.Services
 )
 
 // Service interfaces
@@ -40,6 +41,9 @@ type (
 	SimSwapService      = glide.SimSwapService
 	NumberVerifyService = glide.NumberVerifyService
 	KYCService          = glide.KYCService
+
+	LocationVerificationService = glide.LocationVerificationService
+	DeviceStatusService         = glide.DeviceStatusService
 )
 
 // MagicAuth types
@@ -52,6 +56,8 @@ type (
 	GetPhoneNumberResponse    = glide.GetPhoneNumberResponse
 	SessionInfo               = glide.SessionInfo
 	PLMN                      = glide.PLMN
+	PLMNInfo                  = glide.PLMNInfo
+	PLMNRegistry              = glide.PLMNRegistry
 	ConsentData               = glide.ConsentData
 	ClientInfo                = glide.ClientInfo
 )
@@ -76,6 +82,34 @@ type (
 	KYCMatchResponse = glide.KYCMatchResponse
 	MatchResult      = glide.MatchResult
 	Address          = glide.Address
+
+	KYCCheckResult       = glide.KYCCheckResult
+	KYCAgeVerifyRequest  = glide.KYCAgeVerifyRequest
+	KYCAgeVerifyResponse = glide.KYCAgeVerifyResponse
+	KYCFillInRequest     = glide.KYCFillInRequest
+	KYCFillInResponse    = glide.KYCFillInResponse
+	KYCIdentity          = glide.KYCIdentity
+	KYCField             = glide.KYCField
+	Gender               = glide.Gender
+	MatchThresholds      = glide.MatchThresholds
+	MatchEvaluation      = glide.MatchEvaluation
+)
+
+// DeviceStatus types
+type (
+	DeviceStatusRequest        = glide.DeviceStatusRequest
+	RoamingStatusResponse      = glide.RoamingStatusResponse
+	ConnectivityStatus         = glide.ConnectivityStatus
+	ConnectivityStatusResponse = glide.ConnectivityStatusResponse
+)
+
+// Location verification types
+type (
+	AreaType                   = glide.AreaType
+	Point                      = glide.Point
+	LocationArea               = glide.LocationArea
+	LocationVerificationResult = glide.LocationVerificationResult
+	LocationVerifyResponse     = glide.LocationVerifyResponse
 )
 
 // Logger types
@@ -86,8 +120,145 @@ type (
 	Field     = glide.Field
 )
 
-// Error type
-type Error = glide.Error
+// Circuit breaker types
+type (
+	CircuitState          = glide.CircuitState
+	CircuitBreakerConfig  = glide.CircuitBreakerConfig
+	CircuitBreakerMetrics = glide.CircuitBreakerMetrics
+)
+
+// Rate limiting types
+type (
+	RateLimiter            = glide.RateLimiter
+	RateLimit              = glide.RateLimit
+	RateLimits             = glide.RateLimits
+	LocalRateLimiter       = glide.LocalRateLimiter
+	TokenBucketStore       = glide.TokenBucketStore
+	DistributedRateLimiter = glide.DistributedRateLimiter
+	QuotaState             = glide.QuotaState
+)
+
+// Rate limiter constructors
+var (
+	NewLocalRateLimiter       = glide.NewLocalRateLimiter
+	NewDistributedRateLimiter = glide.NewDistributedRateLimiter
+)
+
+// Rate limiting constants
+const (
+	TokenBucketScript         = glide.TokenBucketScript
+	DefaultRateLimitKeyPrefix = glide.DefaultRateLimitKeyPrefix
+
+	DefaultQuotaWarningThreshold = glide.DefaultQuotaWarningThreshold
+)
+
+// Regional endpoint types
+type (
+	Endpoint       = glide.Endpoint
+	EndpointPolicy = glide.EndpointPolicy
+	EndpointStatus = glide.EndpointStatus
+)
+
+// Endpoint policies
+const (
+	EndpointPolicyFailover      = glide.EndpointPolicyFailover
+	EndpointPolicyLowestLatency = glide.EndpointPolicyLowestLatency
+
+	DefaultEndpointCooldown = glide.DefaultEndpointCooldown
+)
+
+// ParseEndpointPolicy converts "failover" or "lowest_latency" to an EndpointPolicy
+var ParseEndpointPolicy = glide.ParseEndpointPolicy
+
+// Diagnostic types
+type (
+	DiagnosticReport = glide.DiagnosticReport
+	DiagnosticCheck  = glide.DiagnosticCheck
+	DiagnosticStatus = glide.DiagnosticStatus
+)
+
+// Diagnostic statuses and check names
+const (
+	DiagnosticOK      = glide.DiagnosticOK
+	DiagnosticWarning = glide.DiagnosticWarning
+	DiagnosticFailed  = glide.DiagnosticFailed
+	DiagnosticSkipped = glide.DiagnosticSkipped
+
+	CheckConfig   = glide.CheckConfig
+	CheckDNS      = glide.CheckDNS
+	CheckTLS      = glide.CheckTLS
+	CheckEndpoint = glide.CheckEndpoint
+	CheckAuth     = glide.CheckAuth
+	CheckClock    = glide.CheckClock
+
+	MaxClockSkew           = glide.MaxClockSkew
+	DiagnoseInterval       = glide.DiagnoseInterval
+	DefaultHealthCheckPath = glide.DefaultHealthCheckPath
+)
+
+// Multi-tenant types
+type (
+	ClientPool                   = glide.ClientPool
+	ClientPoolConfig             = glide.ClientPoolConfig
+	TenantCredentials            = glide.TenantCredentials
+	TenantCredentialProvider     = glide.TenantCredentialProvider
+	TenantCredentialProviderFunc = glide.TenantCredentialProviderFunc
+)
+
+// NewClientPool creates a pool with one client per tenant
+var NewClientPool = glide.NewClientPool
+
+// Credential types
+type (
+	CredentialProvider       = glide.CredentialProvider
+	CredentialRefresher      = glide.CredentialRefresher
+	CredentialProviderFunc   = glide.CredentialProviderFunc
+	StaticCredentialProvider = glide.StaticCredentialProvider
+	EnvCredentialProvider    = glide.EnvCredentialProvider
+	FileCredentialProvider   = glide.FileCredentialProvider
+)
+
+// Credential provider constructors
+var (
+	NewStaticCredentialProvider = glide.NewStaticCredentialProvider
+	NewFileCredentialProvider   = glide.NewFileCredentialProvider
+)
+
+// DefaultCredentialRefreshInterval is how often a FileCredentialProvider re-reads its file
+const DefaultCredentialRefreshInterval = glide.DefaultCredentialRefreshInterval
+
+// Record/replay types
+type (
+	Cassette       = glide.Cassette
+	CassetteMode   = glide.CassetteMode
+	CassetteOption = glide.CassetteOption
+)
+
+// Record/replay functions
+var (
+	NewCassette          = glide.NewCassette
+	ParseCassetteMode    = glide.ParseCassetteMode
+	WithCassetteMatchKey = glide.WithCassetteMatchKey
+)
+
+// Constants - Cassette Modes
+const (
+	CassetteReplay = glide.CassetteReplay
+	CassetteRecord = glide.CassetteRecord
+)
+
+// Per-call types
+type (
+	CallOption   = glide.CallOption
+	ResponseMeta = glide.ResponseMeta
+)
+
+// Error types
+type (
+	Error           = glide.Error
+	FieldViolation  = glide.FieldViolation
+	ValidationError = glide.ValidationError
+)
 
 // Constants - Use Cases
 const (
@@ -95,6 +266,37 @@ const (
 	UseCaseVerifyPhoneNumber = glide.UseCaseVerifyPhoneNumber
 )
 
+// Constants - Location Verification
+const (
+	AreaTypeCircle  = glide.AreaTypeCircle
+	AreaTypePolygon = glide.AreaTypePolygon
+
+	LocationVerificationTrue    = glide.LocationVerificationTrue
+	LocationVerificationFalse   = glide.LocationVerificationFalse
+	LocationVerificationPartial = glide.LocationVerificationPartial
+	LocationVerificationUnknown = glide.LocationVerificationUnknown
+)
+
+// Constants - Device Connectivity Status
+const (
+	ConnectivityStatusConnectedData = glide.ConnectivityStatusConnectedData
+	ConnectivityStatusConnectedSMS  = glide.ConnectivityStatusConnectedSMS
+	ConnectivityStatusNotConnected  = glide.ConnectivityStatusNotConnected
+)
+
+// Constants - KYC Check Results
+const (
+	KYCCheckTrue         = glide.KYCCheckTrue
+	KYCCheckFalse        = glide.KYCCheckFalse
+	KYCCheckNotAvailable = glide.KYCCheckNotAvailable
+
+	GenderMale   = glide.GenderMale
+	GenderFemale = glide.GenderFemale
+	GenderOther  = glide.GenderOther
+
+	DefaultMatchScoreThreshold = glide.DefaultMatchScoreThreshold
+)
+
 // Constants - Log Formats
 const (
 	LogFormatPretty = glide.LogFormatPretty
@@ -143,6 +345,33 @@ const (
 
 	// 503 Service Unavailable errors
 	ErrCodeServiceUnavailable = glide.ErrCodeServiceUnavailable
+
+	// SDK-side errors
+	ErrCodeCircuitOpen      = glide.ErrCodeCircuitOpen
+	ErrCodeCassetteMismatch = glide.ErrCodeCassetteMismatch
+)
+
+// Constants - Circuit States
+const (
+	CircuitClosed   = glide.CircuitClosed
+	CircuitOpen     = glide.CircuitOpen
+	CircuitHalfOpen = glide.CircuitHalfOpen
+)
+
+// ErrCircuitOpen is returned when a call is rejected by an open circuit breaker
+var ErrCircuitOpen = glide.ErrCircuitOpen
+
+// Constants - Validation Rules
+const (
+	RuleRequired  = glide.RuleRequired
+	RuleForbidden = glide.RuleForbidden
+	RuleFormat    = glide.RuleFormat
+	RuleMinLength = glide.RuleMinLength
+	RuleMaxLength = glide.RuleMaxLength
+	RuleRange     = glide.RuleRange
+	RuleEnum      = glide.RuleEnum
+	RuleURL       = glide.RuleURL
+	RuleRegistry  = glide.RuleRegistry
 )
 
 // Functions
@@ -150,19 +379,62 @@ const (
 // New creates a new Glide client with the given options
 var New = glide.New
 
+// NewWithError creates a new Glide client, rejecting invalid configuration
+var NewWithError = glide.NewWithError
+
+// LoadConfig reads the configuration from a YAML or JSON file and GLIDE_* environment variables
+var LoadConfig = glide.LoadConfig
+
+// DefaultBaseURL is the production API endpoint
+const DefaultBaseURL = glide.DefaultBaseURL
+
+// NewClientWithServices creates a client with some or all services replaced, e.g. by glidemock mocks
+var NewClientWithServices = glide.NewClientWithServices
+
 // Option functions
 var (
-	WithAPIKey      = glide.WithAPIKey
-	WithBaseURL     = glide.WithBaseURL
-	WithTimeout     = glide.WithTimeout
-	WithHTTPClient  = glide.WithHTTPClient
-	WithRetry       = glide.WithRetry
-	WithRateLimit   = glide.WithRateLimit
-	WithNoRateLimit = glide.WithNoRateLimit
-	WithDebug       = glide.WithDebug
-	WithLogLevel    = glide.WithLogLevel
-	WithLogFormat   = glide.WithLogFormat
-	WithLogger      = glide.WithLogger
+	WithConfig                = glide.WithConfig
+	WithAPIKey                = glide.WithAPIKey
+	WithCredentialProvider    = glide.WithCredentialProvider
+	WithEndpoints             = glide.WithEndpoints
+	WithEndpointCooldown      = glide.WithEndpointCooldown
+	WithDataResidency         = glide.WithDataResidency
+	WithHealthCheckPath       = glide.WithHealthCheckPath
+	WithBaseURL               = glide.WithBaseURL
+	WithTimeout               = glide.WithTimeout
+	WithHTTPClient            = glide.WithHTTPClient
+	WithRetry                 = glide.WithRetry
+	WithRateLimit             = glide.WithRateLimit
+	WithNoRateLimit           = glide.WithNoRateLimit
+	WithAutoIdempotencyKeys   = glide.WithAutoIdempotencyKeys
+	WithCircuitBreaker        = glide.WithCircuitBreaker
+	WithOperationRateLimit    = glide.WithOperationRateLimit
+	WithRateLimiter           = glide.WithRateLimiter
+	WithQuotaPacing           = glide.WithQuotaPacing
+	WithQuotaWarningThreshold = glide.WithQuotaWarningThreshold
+	WithDebug                 = glide.WithDebug
+	WithLogLevel              = glide.WithLogLevel
+	WithLogFormat             = glide.WithLogFormat
+	WithLogOutput             = glide.WithLogOutput
+	WithLogger                = glide.WithLogger
+
+	WithPhoneNormalization   = glide.WithPhoneNormalization
+	WithStrictPLMNValidation = glide.WithStrictPLMNValidation
+	WithPLMNRegistry         = glide.WithPLMNRegistry
+	WithKYCNormalization     = glide.WithKYCNormalization
+	WithKYCHashing           = glide.WithKYCHashing
+)
+
+// Call option functions
+var (
+	WithCallTimeout    = glide.WithCallTimeout
+	WithCallRetry      = glide.WithCallRetry
+	WithCallRetryDelay = glide.WithCallRetryDelay
+	WithIdempotencyKey = glide.WithIdempotencyKey
+	WithHeader         = glide.WithHeader
+	WithResponseMeta   = glide.WithResponseMeta
+
+	WithCallDataResidency = glide.WithCallDataResidency
 )
 
 // Error constructors
@@ -177,11 +449,26 @@ var (
 	ValidatePLMN                = glide.ValidatePLMN
 	ValidateConsentData         = glide.ValidateConsentData
 	ValidateUseCaseRequirements = glide.ValidateUseCaseRequirements
+	ValidateLocationArea        = glide.ValidateLocationArea
+	NormalizeCountryCode        = glide.NormalizeCountryCode
+)
+
+// PLMN registry functions
+var (
+	LookupPLMN          = glide.LookupPLMN
+	PLMNsForCountry     = glide.PLMNsForCountry
+	PLMNsForPhoneNumber = glide.PLMNsForPhoneNumber
+	NewPLMNRegistry     = glide.NewPLMNRegistry
+	LoadPLMNRegistry    = glide.LoadPLMNRegistry
+	ValidatePLMNStrict  = glide.ValidatePLMNStrict
 )
 
 // Logger constructors
 var (
-	NewDefaultLogger = glide.NewDefaultLogger
-	NewNoopLogger    = glide.NewNoopLogger
-	ParseLogLevel    = glide.ParseLogLevel
+	NewDefaultLogger           = glide.NewDefaultLogger
+	NewDefaultLoggerWithFormat = glide.NewDefaultLoggerWithFormat
+	NewDefaultLoggerWithOutput = glide.NewDefaultLoggerWithOutput
+	NewNoopLogger              = glide.NewNoopLogger
+	ParseLogLevel              = glide.ParseLogLevel
+	ParseLogFormat             = glide.ParseLogFormat
 )
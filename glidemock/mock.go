@@ -0,0 +1,204 @@
+// Package glidemock provides mock implementations of the Glide service interfaces
+//
+// Mocks answer from expectations set up by the test and record every call.
+// They replace the services entirely, so no HTTP request is made and no SDK
+// validation runs:
+//
+//	mocks := glidemock.New()
+//	mocks.SimSwap.OnCheck().Return(&glide.SimSwapCheckResponse{Swapped: true}, nil)
+//
+//	client := mocks.Client()
+//	resp, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: "+14155551234"})
+//
+//	mocks.AssertExpectations(t)
+package glidemock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+)
+
+// ErrUnexpectedCall is returned when no expectation matches a call
+var ErrUnexpectedCall = errors.New("glidemock: unexpected call")
+
+// TestingT is the subset of testing.TB used to report unmet expectations
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Call is a recorded call to a mocked method
+type Call struct {
+	Method  string // e.g. "SimSwap.Check"
+	Request interface{}
+	Options []glide.CallOption
+	Err     error // Error returned to the caller
+}
+
+// Expectation configures how a mocked method answers
+type Expectation[Req, Resp any] struct {
+	match func(*Req) bool
+	run   func(context.Context, *Req) (*Resp, error)
+	resp  *Resp
+	err   error
+	times int // Number of calls the expectation answers, 0 for unlimited
+	calls int
+}
+
+// Return answers with resp and err
+func (e *Expectation[Req, Resp]) Return(resp *Resp, err error) *Expectation[Req, Resp] {
+	e.resp, e.err = resp, err
+	return e
+}
+
+// ReturnError answers with err
+func (e *Expectation[Req, Resp]) ReturnError(err error) *Expectation[Req, Resp] {
+	return e.Return(nil, err)
+}
+
+// Run answers by calling fn
+func (e *Expectation[Req, Resp]) Run(fn func(ctx context.Context, req *Req) (*Resp, error)) *Expectation[Req, Resp] {
+	e.run = fn
+	return e
+}
+
+// Match restricts the expectation to requests for which fn returns true
+func (e *Expectation[Req, Resp]) Match(fn func(req *Req) bool) *Expectation[Req, Resp] {
+	e.match = fn
+	return e
+}
+
+// Times limits the expectation to n calls, after which later expectations are used
+func (e *Expectation[Req, Resp]) Times(n int) *Expectation[Req, Resp] {
+	e.times = n
+	return e
+}
+
+// Once limits the expectation to a single call
+func (e *Expectation[Req, Resp]) Once() *Expectation[Req, Resp] {
+	return e.Times(1)
+}
+
+// exhausted reports whether the expectation answered all the calls it allows
+func (e *Expectation[Req, Resp]) exhausted() bool {
+	return e.times > 0 && e.calls >= e.times
+}
+
+// unmet describes why the expectation is not satisfied, or returns "" if it is
+func (e *Expectation[Req, Resp]) unmet() string {
+	switch {
+	case e.times > 0 && e.calls < e.times:
+		return fmt.Sprintf("expected %d call(s), got %d", e.times, e.calls)
+	case e.times == 0 && e.calls == 0:
+		return "expected at least one call, got none"
+	}
+	return ""
+}
+
+// recorder keeps the calls made to a mock
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(call Call) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// Calls returns the recorded calls, optionally filtered by method name
+func (r *recorder) Calls(method ...string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, call := range r.calls {
+		if len(method) == 0 || containsString(method, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// method holds the expectations of one mocked method
+type method[Req, Resp any] struct {
+	name string
+	rec  *recorder
+
+	mu           sync.Mutex
+	expectations []*Expectation[Req, Resp]
+}
+
+// on adds an expectation, used after the ones already set
+func (m *method[Req, Resp]) on() *Expectation[Req, Resp] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &Expectation[Req, Resp]{}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// call answers from the first matching expectation that is not exhausted
+func (m *method[Req, Resp]) call(ctx context.Context, req *Req, opts []glide.CallOption) (*Resp, error) {
+	m.mu.Lock()
+	var matched *Expectation[Req, Resp]
+	for _, e := range m.expectations {
+		if e.exhausted() || (e.match != nil && !e.match(req)) {
+			continue
+		}
+		e.calls++
+		matched = e
+		break
+	}
+	m.mu.Unlock()
+
+	var resp *Resp
+	var err error
+	switch {
+	case matched == nil:
+		err = fmt.Errorf("%w to %s", ErrUnexpectedCall, m.name)
+	case matched.run != nil:
+		resp, err = matched.run(ctx, req)
+	default:
+		resp, err = matched.resp, matched.err
+	}
+
+	m.rec.record(Call{Method: m.name, Request: req, Options: opts, Err: err})
+	return resp, err
+}
+
+// assert reports unmet expectations and unexpected calls
+func (m *method[Req, Resp]) assert(t TestingT) bool {
+	t.Helper()
+	ok := true
+
+	m.mu.Lock()
+	for i, e := range m.expectations {
+		if reason := e.unmet(); reason != "" {
+			t.Errorf("glidemock: %s expectation #%d: %s", m.name, i+1, reason)
+			ok = false
+		}
+	}
+	m.mu.Unlock()
+
+	for _, call := range m.rec.Calls(m.name) {
+		if errors.Is(call.Err, ErrUnexpectedCall) {
+			t.Errorf("glidemock: unexpected call to %s with %+v", m.name, call.Request)
+			ok = false
+		}
+	}
+	return ok
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
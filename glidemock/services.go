@@ -0,0 +1,335 @@
+package glidemock
+
+import (
+	"context"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+)
+
+// Compile-time checks that the mocks implement the service interfaces
+var (
+	_ glide.MagicAuthService            = (*MagicAuthService)(nil)
+	_ glide.SimSwapService              = (*SimSwapService)(nil)
+	_ glide.NumberVerifyService         = (*NumberVerifyService)(nil)
+	_ glide.KYCService                  = (*KYCService)(nil)
+	_ glide.LocationVerificationService = (*LocationVerificationService)(nil)
+	_ glide.DeviceStatusService         = (*DeviceStatusService)(nil)
+)
+
+// MagicAuthService is a mock glide.MagicAuthService
+type MagicAuthService struct {
+	recorder
+	prepare           method[glide.PrepareRequest, glide.PrepareResponse]
+	verifyPhoneNumber method[glide.VerifyPhoneNumberRequest, glide.VerifyPhoneNumberResponse]
+	getPhoneNumber    method[glide.GetPhoneNumberRequest, glide.GetPhoneNumberResponse]
+}
+
+// NewMagicAuthService creates a MagicAuth mock with no expectations
+func NewMagicAuthService() *MagicAuthService {
+	m := &MagicAuthService{}
+	m.prepare = method[glide.PrepareRequest, glide.PrepareResponse]{name: "MagicAuth.Prepare", rec: &m.recorder}
+	m.verifyPhoneNumber = method[glide.VerifyPhoneNumberRequest, glide.VerifyPhoneNumberResponse]{name: "MagicAuth.VerifyPhoneNumber", rec: &m.recorder}
+	m.getPhoneNumber = method[glide.GetPhoneNumberRequest, glide.GetPhoneNumberResponse]{name: "MagicAuth.GetPhoneNumber", rec: &m.recorder}
+	return m
+}
+
+// OnPrepare adds an expectation for Prepare
+func (m *MagicAuthService) OnPrepare() *Expectation[glide.PrepareRequest, glide.PrepareResponse] {
+	return m.prepare.on()
+}
+
+// OnVerifyPhoneNumber adds an expectation for VerifyPhoneNumber
+func (m *MagicAuthService) OnVerifyPhoneNumber() *Expectation[glide.VerifyPhoneNumberRequest, glide.VerifyPhoneNumberResponse] {
+	return m.verifyPhoneNumber.on()
+}
+
+// OnGetPhoneNumber adds an expectation for GetPhoneNumber
+func (m *MagicAuthService) OnGetPhoneNumber() *Expectation[glide.GetPhoneNumberRequest, glide.GetPhoneNumberResponse] {
+	return m.getPhoneNumber.on()
+}
+
+// Prepare implements glide.MagicAuthService
+func (m *MagicAuthService) Prepare(ctx context.Context, req *glide.PrepareRequest, opts ...glide.CallOption) (*glide.PrepareResponse, error) {
+	return m.prepare.call(ctx, req, opts)
+}
+
+// VerifyPhoneNumber implements glide.MagicAuthService
+func (m *MagicAuthService) VerifyPhoneNumber(ctx context.Context, req *glide.VerifyPhoneNumberRequest, opts ...glide.CallOption) (*glide.VerifyPhoneNumberResponse, error) {
+	return m.verifyPhoneNumber.call(ctx, req, opts)
+}
+
+// GetPhoneNumber implements glide.MagicAuthService
+func (m *MagicAuthService) GetPhoneNumber(ctx context.Context, req *glide.GetPhoneNumberRequest, opts ...glide.CallOption) (*glide.GetPhoneNumberResponse, error) {
+	return m.getPhoneNumber.call(ctx, req, opts)
+}
+
+// AssertExpectations reports unmet expectations and unexpected calls
+func (m *MagicAuthService) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	return assertAll(t, m.prepare.assert, m.verifyPhoneNumber.assert, m.getPhoneNumber.assert)
+}
+
+// SimSwapService is a mock glide.SimSwapService
+type SimSwapService struct {
+	recorder
+	check           method[glide.SimSwapCheckRequest, glide.SimSwapCheckResponse]
+	getLastSwapDate method[glide.SimSwapDateRequest, glide.SimSwapDateResponse]
+}
+
+// NewSimSwapService creates a SimSwap mock with no expectations
+func NewSimSwapService() *SimSwapService {
+	m := &SimSwapService{}
+	m.check = method[glide.SimSwapCheckRequest, glide.SimSwapCheckResponse]{name: "SimSwap.Check", rec: &m.recorder}
+	m.getLastSwapDate = method[glide.SimSwapDateRequest, glide.SimSwapDateResponse]{name: "SimSwap.GetLastSwapDate", rec: &m.recorder}
+	return m
+}
+
+// OnCheck adds an expectation for Check
+func (m *SimSwapService) OnCheck() *Expectation[glide.SimSwapCheckRequest, glide.SimSwapCheckResponse] {
+	return m.check.on()
+}
+
+// OnGetLastSwapDate adds an expectation for GetLastSwapDate
+func (m *SimSwapService) OnGetLastSwapDate() *Expectation[glide.SimSwapDateRequest, glide.SimSwapDateResponse] {
+	return m.getLastSwapDate.on()
+}
+
+// Check implements glide.SimSwapService
+func (m *SimSwapService) Check(ctx context.Context, req *glide.SimSwapCheckRequest, opts ...glide.CallOption) (*glide.SimSwapCheckResponse, error) {
+	return m.check.call(ctx, req, opts)
+}
+
+// GetLastSwapDate implements glide.SimSwapService
+func (m *SimSwapService) GetLastSwapDate(ctx context.Context, req *glide.SimSwapDateRequest, opts ...glide.CallOption) (*glide.SimSwapDateResponse, error) {
+	return m.getLastSwapDate.call(ctx, req, opts)
+}
+
+// AssertExpectations reports unmet expectations and unexpected calls
+func (m *SimSwapService) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	return assertAll(t, m.check.assert, m.getLastSwapDate.assert)
+}
+
+// NumberVerifyService is a mock glide.NumberVerifyService
+type NumberVerifyService struct {
+	recorder
+	verify method[glide.NumberVerifyRequest, glide.NumberVerifyResponse]
+}
+
+// NewNumberVerifyService creates a NumberVerify mock with no expectations
+func NewNumberVerifyService() *NumberVerifyService {
+	m := &NumberVerifyService{}
+	m.verify = method[glide.NumberVerifyRequest, glide.NumberVerifyResponse]{name: "NumberVerify.Verify", rec: &m.recorder}
+	return m
+}
+
+// OnVerify adds an expectation for Verify
+func (m *NumberVerifyService) OnVerify() *Expectation[glide.NumberVerifyRequest, glide.NumberVerifyResponse] {
+	return m.verify.on()
+}
+
+// Verify implements glide.NumberVerifyService
+func (m *NumberVerifyService) Verify(ctx context.Context, req *glide.NumberVerifyRequest, opts ...glide.CallOption) (*glide.NumberVerifyResponse, error) {
+	return m.verify.call(ctx, req, opts)
+}
+
+// AssertExpectations reports unmet expectations and unexpected calls
+func (m *NumberVerifyService) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	return m.verify.assert(t)
+}
+
+// KYCService is a mock glide.KYCService
+type KYCService struct {
+	recorder
+	match     method[glide.KYCMatchRequest, glide.KYCMatchResponse]
+	ageVerify method[glide.KYCAgeVerifyRequest, glide.KYCAgeVerifyResponse]
+	fillIn    method[glide.KYCFillInRequest, glide.KYCFillInResponse]
+}
+
+// NewKYCService creates a KYC mock with no expectations
+func NewKYCService() *KYCService {
+	m := &KYCService{}
+	m.match = method[glide.KYCMatchRequest, glide.KYCMatchResponse]{name: "KYC.Match", rec: &m.recorder}
+	m.ageVerify = method[glide.KYCAgeVerifyRequest, glide.KYCAgeVerifyResponse]{name: "KYC.AgeVerify", rec: &m.recorder}
+	m.fillIn = method[glide.KYCFillInRequest, glide.KYCFillInResponse]{name: "KYC.FillIn", rec: &m.recorder}
+	return m
+}
+
+// OnMatch adds an expectation for Match
+func (m *KYCService) OnMatch() *Expectation[glide.KYCMatchRequest, glide.KYCMatchResponse] {
+	return m.match.on()
+}
+
+// OnAgeVerify adds an expectation for AgeVerify
+func (m *KYCService) OnAgeVerify() *Expectation[glide.KYCAgeVerifyRequest, glide.KYCAgeVerifyResponse] {
+	return m.ageVerify.on()
+}
+
+// OnFillIn adds an expectation for FillIn
+func (m *KYCService) OnFillIn() *Expectation[glide.KYCFillInRequest, glide.KYCFillInResponse] {
+	return m.fillIn.on()
+}
+
+// Match implements glide.KYCService
+func (m *KYCService) Match(ctx context.Context, req *glide.KYCMatchRequest, opts ...glide.CallOption) (*glide.KYCMatchResponse, error) {
+	return m.match.call(ctx, req, opts)
+}
+
+// AgeVerify implements glide.KYCService
+func (m *KYCService) AgeVerify(ctx context.Context, req *glide.KYCAgeVerifyRequest, opts ...glide.CallOption) (*glide.KYCAgeVerifyResponse, error) {
+	return m.ageVerify.call(ctx, req, opts)
+}
+
+// FillIn implements glide.KYCService
+func (m *KYCService) FillIn(ctx context.Context, req *glide.KYCFillInRequest, opts ...glide.CallOption) (*glide.KYCFillInResponse, error) {
+	return m.fillIn.call(ctx, req, opts)
+}
+
+// AssertExpectations reports unmet expectations and unexpected calls
+func (m *KYCService) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	return assertAll(t, m.match.assert, m.ageVerify.assert, m.fillIn.assert)
+}
+
+// LocationVerifyRequest holds the arguments of LocationVerificationService.Verify
+type LocationVerifyRequest struct {
+	PhoneNumber string
+	Area        *glide.LocationArea
+	MaxAge      int
+}
+
+// LocationVerificationService is a mock glide.LocationVerificationService
+type LocationVerificationService struct {
+	recorder
+	verify method[LocationVerifyRequest, glide.LocationVerifyResponse]
+}
+
+// NewLocationVerificationService creates a LocationVerification mock with no expectations
+func NewLocationVerificationService() *LocationVerificationService {
+	m := &LocationVerificationService{}
+	m.verify = method[LocationVerifyRequest, glide.LocationVerifyResponse]{name: "LocationVerification.Verify", rec: &m.recorder}
+	return m
+}
+
+// OnVerify adds an expectation for Verify
+func (m *LocationVerificationService) OnVerify() *Expectation[LocationVerifyRequest, glide.LocationVerifyResponse] {
+	return m.verify.on()
+}
+
+// Verify implements glide.LocationVerificationService
+func (m *LocationVerificationService) Verify(ctx context.Context, phoneNumber string, area *glide.LocationArea, maxAge int, opts ...glide.CallOption) (*glide.LocationVerifyResponse, error) {
+	return m.verify.call(ctx, &LocationVerifyRequest{PhoneNumber: phoneNumber, Area: area, MaxAge: maxAge}, opts)
+}
+
+// AssertExpectations reports unmet expectations and unexpected calls
+func (m *LocationVerificationService) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	return m.verify.assert(t)
+}
+
+// DeviceStatusService is a mock glide.DeviceStatusService
+type DeviceStatusService struct {
+	recorder
+	roaming      method[glide.DeviceStatusRequest, glide.RoamingStatusResponse]
+	connectivity method[glide.DeviceStatusRequest, glide.ConnectivityStatusResponse]
+}
+
+// NewDeviceStatusService creates a DeviceStatus mock with no expectations
+func NewDeviceStatusService() *DeviceStatusService {
+	m := &DeviceStatusService{}
+	m.roaming = method[glide.DeviceStatusRequest, glide.RoamingStatusResponse]{name: "DeviceStatus.GetRoamingStatus", rec: &m.recorder}
+	m.connectivity = method[glide.DeviceStatusRequest, glide.ConnectivityStatusResponse]{name: "DeviceStatus.GetConnectivityStatus", rec: &m.recorder}
+	return m
+}
+
+// OnGetRoamingStatus adds an expectation for GetRoamingStatus
+func (m *DeviceStatusService) OnGetRoamingStatus() *Expectation[glide.DeviceStatusRequest, glide.RoamingStatusResponse] {
+	return m.roaming.on()
+}
+
+// OnGetConnectivityStatus adds an expectation for GetConnectivityStatus
+func (m *DeviceStatusService) OnGetConnectivityStatus() *Expectation[glide.DeviceStatusRequest, glide.ConnectivityStatusResponse] {
+	return m.connectivity.on()
+}
+
+// GetRoamingStatus implements glide.DeviceStatusService
+func (m *DeviceStatusService) GetRoamingStatus(ctx context.Context, req *glide.DeviceStatusRequest, opts ...glide.CallOption) (*glide.RoamingStatusResponse, error) {
+	return m.roaming.call(ctx, req, opts)
+}
+
+// GetConnectivityStatus implements glide.DeviceStatusService
+func (m *DeviceStatusService) GetConnectivityStatus(ctx context.Context, req *glide.DeviceStatusRequest, opts ...glide.CallOption) (*glide.ConnectivityStatusResponse, error) {
+	return m.connectivity.call(ctx, req, opts)
+}
+
+// AssertExpectations reports unmet expectations and unexpected calls
+func (m *DeviceStatusService) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	return assertAll(t, m.roaming.assert, m.connectivity.assert)
+}
+
+// Mocks bundles a mock for every service
+type Mocks struct {
+	MagicAuth    *MagicAuthService
+	SimSwap      *SimSwapService
+	NumberVerify *NumberVerifyService
+	KYC          *KYCService
+
+	LocationVerification *LocationVerificationService
+	DeviceStatus         *DeviceStatusService
+}
+
+// New creates mocks for every service with no expectations
+func New() *Mocks {
+	return &Mocks{
+		MagicAuth:            NewMagicAuthService(),
+		SimSwap:              NewSimSwapService(),
+		NumberVerify:         NewNumberVerifyService(),
+		KYC:                  NewKYCService(),
+		LocationVerification: NewLocationVerificationService(),
+		DeviceStatus:         NewDeviceStatusService(),
+	}
+}
+
+// Services returns the mocks for use with glide.NewClientWithServices
+func (m *Mocks) Services() glide.Services {
+	return glide.Services{
+		MagicAuth:            m.MagicAuth,
+		SimSwap:              m.SimSwap,
+		NumberVerify:         m.NumberVerify,
+		KYC:                  m.KYC,
+		LocationVerification: m.LocationVerification,
+		DeviceStatus:         m.DeviceStatus,
+	}
+}
+
+// Client returns a client backed entirely by the mocks
+func (m *Mocks) Client(opts ...glide.Option) *glide.Client {
+	return glide.NewClientWithServices(m.Services(), opts...)
+}
+
+// AssertExpectations reports unmet expectations and unexpected calls on every mock
+func (m *Mocks) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	return assertAll(t,
+		m.MagicAuth.AssertExpectations,
+		m.SimSwap.AssertExpectations,
+		m.NumberVerify.AssertExpectations,
+		m.KYC.AssertExpectations,
+		m.LocationVerification.AssertExpectations,
+		m.DeviceStatus.AssertExpectations,
+	)
+}
+
+// assertAll runs every check, reporting all failures rather than stopping at the first
+func assertAll(t TestingT, checks ...func(TestingT) bool) bool {
+	t.Helper()
+	ok := true
+	for _, check := range checks {
+		if !check(t) {
+			ok = false
+		}
+	}
+	return ok
+}
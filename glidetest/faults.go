@@ -0,0 +1,354 @@
+package glidetest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Fault is a failure injected into a single request
+type Fault int
+
+const (
+	// FaultNone passes the request through unchanged
+	FaultNone Fault = iota
+
+	// FaultConnectionReset fails the request with a connection reset before any response
+	FaultConnectionReset
+
+	// FaultTimeout blocks until the request is cancelled, or the WithTimeoutAfter delay, then fails with a timeout
+	FaultTimeout
+
+	// FaultSlowBody delivers the real response body in small chunks with a delay before each
+	FaultSlowBody
+
+	// FaultTruncatedJSON cuts the real response body in half
+	FaultTruncatedJSON
+
+	// FaultServerError answers with a 5xx status and no body, without reaching the server
+	FaultServerError
+
+	// FaultMalformedErrorBody answers with an error status and a body that is not a Glide error,
+	// like an HTML page from a proxy, without reaching the server
+	FaultMalformedErrorBody
+)
+
+// String returns the fault name
+func (f Fault) String() string {
+	switch f {
+	case FaultNone:
+		return "none"
+	case FaultConnectionReset:
+		return "connection-reset"
+	case FaultTimeout:
+		return "timeout"
+	case FaultSlowBody:
+		return "slow-body"
+	case FaultTruncatedJSON:
+		return "truncated-json"
+	case FaultServerError:
+		return "server-error"
+	case FaultMalformedErrorBody:
+		return "malformed-error-body"
+	default:
+		return "unknown"
+	}
+}
+
+// Repeat returns fault n times, e.g. for a burst of 5xx responses
+func Repeat(fault Fault, n int) []Fault {
+	faults := make([]Fault, n)
+	for i := range faults {
+		faults[i] = fault
+	}
+	return faults
+}
+
+// FaultOption configures a FaultTransport
+type FaultOption func(*FaultTransport)
+
+// WithFaultProbability injects fault into each request with probability p (0 to 1)
+// Probabilities of all faults add up; the first fault drawn wins
+func WithFaultProbability(fault Fault, p float64) FaultOption {
+	return func(t *FaultTransport) {
+		t.probabilities = append(t.probabilities, faultProbability{fault: fault, p: p})
+	}
+}
+
+// WithFaultSeed makes probabilistic faults reproducible
+func WithFaultSeed(seed int64) FaultOption {
+	return func(t *FaultTransport) {
+		t.rand = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithFaultPaths restricts fault injection to requests whose path starts with one of the prefixes
+func WithFaultPaths(prefixes ...string) FaultOption {
+	return func(t *FaultTransport) {
+		t.paths = prefixes
+	}
+}
+
+// WithServerErrorStatus sets the status used by FaultServerError (default 503)
+func WithServerErrorStatus(status int) FaultOption {
+	return func(t *FaultTransport) {
+		t.serverErrorStatus = status
+	}
+}
+
+// WithMalformedErrorStatus sets the status used by FaultMalformedErrorBody (default 502)
+func WithMalformedErrorStatus(status int) FaultOption {
+	return func(t *FaultTransport) {
+		t.malformedErrorStatus = status
+	}
+}
+
+// WithSlowBodyDelay sets the delay before each chunk of a FaultSlowBody body (default 50ms)
+func WithSlowBodyDelay(delay time.Duration) FaultOption {
+	return func(t *FaultTransport) {
+		t.slowBodyDelay = delay
+	}
+}
+
+// WithTimeoutAfter makes FaultTimeout fail after d instead of waiting for the request to be cancelled
+func WithTimeoutAfter(d time.Duration) FaultOption {
+	return func(t *FaultTransport) {
+		t.timeoutAfter = d
+	}
+}
+
+// faultProbability is the chance of a fault in probabilistic mode
+type faultProbability struct {
+	fault Fault
+	p     float64
+}
+
+// slowBodyChunk is the number of bytes delivered per chunk by FaultSlowBody
+const slowBodyChunk = 16
+
+// malformedErrorBody is the body sent by FaultMalformedErrorBody
+const malformedErrorBody = "<html><body><h1>Bad Gateway</h1></body></html>"
+
+// FaultTransport is an http.RoundTripper that injects faults into SDK traffic
+//
+// Scripted faults are used first, one per request, in order. When the script is
+// empty, faults are drawn from the configured probabilities. Use it with
+// glide.WithHTTPClient(transport.Client()).
+type FaultTransport struct {
+	next http.RoundTripper
+
+	probabilities        []faultProbability
+	paths                []string
+	serverErrorStatus    int
+	malformedErrorStatus int
+	slowBodyDelay        time.Duration
+	timeoutAfter         time.Duration
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	script   []Fault
+	injected []Fault
+}
+
+// NewFaultTransport wraps next, or http.DefaultTransport if nil, with fault injection
+func NewFaultTransport(next http.RoundTripper, opts ...FaultOption) *FaultTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &FaultTransport{
+		next:                 next,
+		serverErrorStatus:    http.StatusServiceUnavailable,
+		malformedErrorStatus: http.StatusBadGateway,
+		slowBodyDelay:        50 * time.Millisecond,
+		rand:                 rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Client returns an HTTP client using the transport, for use with glide.WithHTTPClient
+func (t *FaultTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// Script queues faults for the next requests, one per request
+func (t *FaultTransport) Script(faults ...Fault) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.script = append(t.script, faults...)
+}
+
+// Injected returns the fault applied to each request so far, FaultNone for pass-through requests
+func (t *FaultTransport) Injected() []Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Fault(nil), t.injected...)
+}
+
+// RoundTrip applies the next fault to the request
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := t.nextFault(req.URL.Path)
+
+	switch fault {
+	case FaultConnectionReset:
+		drain(req)
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	case FaultTimeout:
+		drain(req)
+		return nil, t.hang(req.Context())
+	case FaultServerError:
+		drain(req)
+		return syntheticResponse(req, t.serverErrorStatus, "application/json", nil), nil
+	case FaultMalformedErrorBody:
+		drain(req)
+		return syntheticResponse(req, t.malformedErrorStatus, "text/html", []byte(malformedErrorBody)), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || fault == FaultNone {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	switch fault {
+	case FaultTruncatedJSON:
+		body = body[:len(body)/2]
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	case FaultSlowBody:
+		resp.Body = &slowBody{ctx: req.Context(), data: body, delay: t.slowBodyDelay}
+	}
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// nextFault picks the fault for a request and records it
+func (t *FaultTransport) nextFault(path string) Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fault := FaultNone
+	switch {
+	case !t.appliesTo(path):
+	case len(t.script) > 0:
+		fault = t.script[0]
+		t.script = t.script[1:]
+	default:
+		draw := t.rand.Float64()
+		for _, fp := range t.probabilities {
+			if draw < fp.p {
+				fault = fp.fault
+				break
+			}
+			draw -= fp.p
+		}
+	}
+	t.injected = append(t.injected, fault)
+	return fault
+}
+
+// appliesTo reports whether faults are injected for path
+func (t *FaultTransport) appliesTo(path string) bool {
+	if len(t.paths) == 0 {
+		return true
+	}
+	for _, prefix := range t.paths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hang waits like an unresponsive server and returns a timeout error
+func (t *FaultTransport) hang(ctx context.Context) error {
+	var timeout <-chan time.Time
+	if t.timeoutAfter > 0 {
+		timer := time.NewTimer(t.timeoutAfter)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-timeout:
+		return &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// timeoutError is a net.Error reporting a timeout
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// slowBody delivers data in chunks, waiting before each one
+type slowBody struct {
+	ctx   context.Context
+	data  []byte
+	delay time.Duration
+}
+
+func (b *slowBody) Read(p []byte) (int, error) {
+	if len(b.data) == 0 {
+		return 0, io.EOF
+	}
+	timer := time.NewTimer(b.delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-b.ctx.Done():
+		return 0, b.ctx.Err()
+	}
+
+	chunk := b.data
+	if len(chunk) > slowBodyChunk {
+		chunk = chunk[:slowBodyChunk]
+	}
+	n := copy(p, chunk)
+	b.data = b.data[n:]
+	return n, nil
+}
+
+func (b *slowBody) Close() error {
+	return nil
+}
+
+// drain consumes the request body as a server would before failing
+func drain(req *http.Request) {
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+}
+
+// syntheticResponse builds a response that never reached the server
+func syntheticResponse(req *http.Request, status int, contentType string, body []byte) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", contentType)
+	return &http.Response{
+		Status:        http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
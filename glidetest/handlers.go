@@ -0,0 +1,263 @@
+package glidetest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+)
+
+// handlerFunc answers a decoded request body
+type handlerFunc func(w http.ResponseWriter, body map[string]interface{})
+
+// handlers returns the default handler for each endpoint
+func (s *Server) handlers() map[string]handlerFunc {
+	return map[string]handlerFunc{
+		PathPrepare:              s.prepare,
+		PathVerifyPhoneNumber:    s.verifyPhoneNumber,
+		PathGetPhoneNumber:       s.getPhoneNumber,
+		PathSimSwapCheck:         phoneNumberHandler(simSwapCheck),
+		PathSimSwapDate:          phoneNumberHandler(simSwapDate),
+		PathNumberVerify:         phoneNumberHandler(numberVerify),
+		PathKYCMatch:             phoneNumberHandler(kycMatch),
+		PathKYCAgeVerification:   phoneNumberHandler(kycAgeVerification),
+		PathKYCFillIn:            phoneNumberHandler(kycFillIn),
+		PathLocationVerification: phoneNumberHandler(locationVerification),
+		PathDeviceRoaming:        phoneNumberHandler(deviceRoaming),
+		PathDeviceConnectivity:   phoneNumberHandler(deviceConnectivity),
+	}
+}
+
+// prepare creates a session unless the carrier or browser is not eligible
+// Israeli numbers and MCC 425 are treated as ineligible carriers, like the test fixtures of the real API
+func (s *Server) prepare(w http.ResponseWriter, body map[string]interface{}) {
+	useCase := stringField(body, "use_case")
+	phoneNumber := stringField(body, "phone_number")
+	plmn, _ := body["plmn"].(map[string]interface{})
+
+	if useCase != string(glide.UseCaseGetPhoneNumber) && useCase != string(glide.UseCaseVerifyPhoneNumber) {
+		writeError(w, http.StatusBadRequest, glide.ErrCodeValidationError, "Invalid use case")
+		return
+	}
+	if phoneNumber == "" && plmn == nil {
+		writeError(w, http.StatusBadRequest, glide.ErrCodeMissingParameters, "Either phone_number or plmn is required")
+		return
+	}
+	if strings.HasPrefix(phoneNumber, "+972") || stringField(plmn, "mcc") == "425" {
+		CarrierNotEligible().write(w)
+		return
+	}
+	if clientInfo, ok := body["client_info"].(map[string]interface{}); ok {
+		if sc := browserScenario(stringField(clientInfo, "user_agent")); sc != nil {
+			sc.write(w)
+			return
+		}
+	}
+
+	sessionKey := randomHex(16)
+	s.mu.Lock()
+	s.sessions[sessionKey] = session{
+		useCase:     useCase,
+		phoneNumber: phoneNumber,
+		expiresAt:   time.Now().Add(s.sessionTTL),
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"authentication_strategy": glide.AuthenticationStrategyTS43,
+		"session": map[string]interface{}{
+			"session_key":   sessionKey,
+			"protocol_type": "openid4vp",
+			"metadata": map[string]string{
+				"nonce":   stringField(body, "nonce"),
+				"enc_key": randomHex(32),
+			},
+		},
+		"data": map[string]interface{}{
+			"protocol": "openid4vp-v1-unsigned",
+			"request": map[string]interface{}{
+				"nonce":         stringField(body, "nonce"),
+				"response_mode": "dc_api",
+			},
+		},
+		"ttl": int(s.sessionTTL / time.Second),
+	})
+}
+
+// browserScenario returns the error for browsers without Digital Credentials API support
+// Chromium-based browsers are supported; Safari on iOS is reported as an ineligible carrier
+func browserScenario(userAgent string) *Scenario {
+	if userAgent == "" || strings.Contains(userAgent, "Chrome/") {
+		return nil
+	}
+	if strings.Contains(userAgent, "iPhone") || strings.Contains(userAgent, "iPad") {
+		sc := CarrierNotEligible()
+		return &sc
+	}
+	if strings.Contains(userAgent, "Firefox/") || strings.Contains(userAgent, "Safari/") {
+		sc := UnsupportedPlatform()
+		return &sc
+	}
+	return nil
+}
+
+// verifyPhoneNumber consumes a verify_phone_number session
+func (s *Server) verifyPhoneNumber(w http.ResponseWriter, body map[string]interface{}) {
+	sess, ok := s.consumeSession(w, body, glide.UseCaseVerifyPhoneNumber)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"phone_number": sess.phoneNumber,
+		"verified":     true,
+	})
+}
+
+// getPhoneNumber consumes a get_phone_number session
+func (s *Server) getPhoneNumber(w http.ResponseWriter, body map[string]interface{}) {
+	sess, ok := s.consumeSession(w, body, glide.UseCaseGetPhoneNumber)
+	if !ok {
+		return
+	}
+	phoneNumber := sess.phoneNumber
+	if phoneNumber == "" {
+		phoneNumber = DefaultPhoneNumber
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"phone_number": phoneNumber,
+	})
+}
+
+// consumeSession validates the credential and removes the session so it cannot be reused
+func (s *Server) consumeSession(w http.ResponseWriter, body map[string]interface{}, useCase glide.UseCase) (session, bool) {
+	var sessionKey string
+	switch v := body["session"].(type) {
+	case string:
+		sessionKey = v
+	case map[string]interface{}:
+		sessionKey = stringField(v, "session_key")
+	}
+	if sessionKey == "" {
+		writeError(w, http.StatusBadRequest, glide.ErrCodeMissingParameters, "Session is required")
+		return session{}, false
+	}
+	credential := stringField(body, "credential")
+	if credential == "" || credential == "{}" || credential == "null" {
+		writeError(w, http.StatusUnprocessableEntity, glide.ErrCodeInvalidCredentialFormat, "Credential is missing or malformed")
+		return session{}, false
+	}
+
+	s.mu.Lock()
+	sess, ok := s.sessions[sessionKey]
+	if ok {
+		delete(s.sessions, sessionKey)
+	}
+	s.mu.Unlock()
+
+	if !ok || time.Now().After(sess.expiresAt) {
+		SessionExpired().write(w)
+		return session{}, false
+	}
+	if sess.useCase != string(useCase) {
+		writeError(w, http.StatusBadRequest, glide.ErrCodeValidationError, "Session was prepared for "+sess.useCase)
+		return session{}, false
+	}
+	return sess, true
+}
+
+// phoneNumberHandler requires an E.164 phone number before calling next
+func phoneNumberHandler(next func(body map[string]interface{}, now time.Time) interface{}) handlerFunc {
+	return func(w http.ResponseWriter, body map[string]interface{}) {
+		phoneNumber := stringField(body, "phone_number")
+		if phoneNumber == "" {
+			writeError(w, http.StatusBadRequest, glide.ErrCodeMissingParameters, "Phone number is required")
+			return
+		}
+		if !strings.HasPrefix(phoneNumber, "+") {
+			writeError(w, http.StatusBadRequest, glide.ErrCodeValidationError, "Phone number must be in E.164 format")
+			return
+		}
+		writeJSON(w, http.StatusOK, next(body, time.Now().UTC()))
+	}
+}
+
+func simSwapCheck(body map[string]interface{}, now time.Time) interface{} {
+	return map[string]interface{}{"swapped": false, "checked_at": now}
+}
+
+func simSwapDate(body map[string]interface{}, now time.Time) interface{} {
+	return map[string]interface{}{"last_swap_date": now.AddDate(-1, 0, 0).Truncate(time.Hour), "checked_at": now}
+}
+
+func numberVerify(body map[string]interface{}, now time.Time) interface{} {
+	return map[string]interface{}{"verified": true, "checked_at": now}
+}
+
+// kycMatch matches every provided field
+func kycMatch(body map[string]interface{}, now time.Time) interface{} {
+	results := make(map[string]interface{})
+	for field := range body {
+		if field != "phone_number" {
+			results[field] = map[string]interface{}{"matched": true, "result": glide.KYCCheckTrue}
+		}
+	}
+	return map[string]interface{}{"match_results": results, "overall_match": true, "checked_at": now}
+}
+
+func kycAgeVerification(body map[string]interface{}, now time.Time) interface{} {
+	resp := map[string]interface{}{"age_check": glide.KYCCheckTrue, "verified_status": true, "checked_at": now}
+	results := make(map[string]interface{})
+	for field := range body {
+		switch field {
+		case "phone_number", "age_threshold", "include_content_lock", "include_parental_control":
+		default:
+			results[field] = map[string]interface{}{"matched": true, "result": glide.KYCCheckTrue}
+		}
+	}
+	if len(results) > 0 {
+		resp["match_results"] = results
+		resp["identity_match_score"] = 100
+	}
+	return resp
+}
+
+func kycFillIn(body map[string]interface{}, now time.Time) interface{} {
+	return map[string]interface{}{
+		"identity": map[string]interface{}{
+			"given_name":  "Jane",
+			"family_name": "Doe",
+			"name":        "Jane Doe",
+			"birth_date":  "1990-01-01",
+			"country":     "US",
+		},
+		"checked_at": now,
+	}
+}
+
+func locationVerification(body map[string]interface{}, now time.Time) interface{} {
+	return map[string]interface{}{"verification_result": glide.LocationVerificationTrue, "last_location_time": now, "checked_at": now}
+}
+
+func deviceRoaming(body map[string]interface{}, now time.Time) interface{} {
+	return map[string]interface{}{"roaming": false, "last_status_time": now, "checked_at": now}
+}
+
+func deviceConnectivity(body map[string]interface{}, now time.Time) interface{} {
+	return map[string]interface{}{"connectivity_status": glide.ConnectivityStatusConnectedData, "last_status_time": now, "checked_at": now}
+}
+
+// stringField returns a string value from a decoded JSON object
+func stringField(m map[string]interface{}, key string) string {
+	value, _ := m[key].(string)
+	return value
+}
+
+// randomHex returns n random bytes hex encoded
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
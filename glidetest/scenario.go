@@ -0,0 +1,106 @@
+package glidetest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+)
+
+// Scenario overrides how the fake answers a request
+// A scenario with no Status and no Body only adds its Latency and Header to the default response
+type Scenario struct {
+	Status  int               // HTTP status to return, 0 to use the endpoint's default handler
+	Code    string            // Error code, for error statuses
+	Message string            // Error message, for error statuses
+	Body    interface{}       // Response body, encoded as JSON; overrides Code and Message
+	Header  map[string]string // Extra response headers
+	Latency time.Duration     // Delay before answering
+}
+
+// handled reports whether the scenario produces the response itself
+func (sc Scenario) handled() bool {
+	return sc.Status != 0 || sc.Body != nil
+}
+
+// delayed returns sc with the latency and headers of a scenario that does not answer itself
+func (sc Scenario) delayed(by Scenario) Scenario {
+	sc.Latency += by.Latency
+	header := make(map[string]string, len(sc.Header)+len(by.Header))
+	for name, value := range sc.Header {
+		header[name] = value
+	}
+	for name, value := range by.Header {
+		header[name] = value
+	}
+	sc.Header = header
+	return sc
+}
+
+// writeHeader sets the scripted response headers
+func (sc Scenario) writeHeader(w http.ResponseWriter) {
+	for name, value := range sc.Header {
+		w.Header().Set(name, value)
+	}
+}
+
+// write writes the scripted response
+func (sc Scenario) write(w http.ResponseWriter) {
+	sc.writeHeader(w)
+
+	status := sc.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	switch {
+	case sc.Body != nil:
+		writeJSON(w, status, sc.Body)
+	case status >= http.StatusBadRequest:
+		writeError(w, status, sc.Code, sc.Message)
+	default:
+		w.WriteHeader(status)
+	}
+}
+
+// Respond answers with status and body
+func Respond(status int, body interface{}) Scenario {
+	return Scenario{Status: status, Body: body}
+}
+
+// Error answers with an error in the Glide API format
+func Error(status int, code, message string) Scenario {
+	return Scenario{Status: status, Code: code, Message: message}
+}
+
+// Latency delays the default response by d
+func Latency(d time.Duration) Scenario {
+	return Scenario{Latency: d}
+}
+
+// CarrierNotEligible answers as if the subscriber's carrier does not support the API
+func CarrierNotEligible() Scenario {
+	return Error(http.StatusUnprocessableEntity, glide.ErrCodeCarrierNotEligible, "Carrier not eligible for this authentication method")
+}
+
+// UnsupportedPlatform answers as if the client browser is not supported
+func UnsupportedPlatform() Scenario {
+	return Error(http.StatusUnprocessableEntity, glide.ErrCodeUnsupportedPlatform, "Browser not supported for this authentication method")
+}
+
+// SessionExpired answers as if the magic auth session expired or never existed
+func SessionExpired() Scenario {
+	return Error(http.StatusNotFound, glide.ErrCodeSessionNotFound, "Session not found or expired")
+}
+
+// RateLimited answers 429 with a Retry-After header
+func RateLimited(retryAfter time.Duration) Scenario {
+	sc := Error(http.StatusTooManyRequests, glide.ErrCodeRateLimitExceeded, "Too many requests")
+	sc.Header = map[string]string{"Retry-After": strconv.Itoa(int(retryAfter.Round(time.Second) / time.Second))}
+	return sc
+}
+
+// ServiceUnavailable answers 503
+func ServiceUnavailable() Scenario {
+	return Error(http.StatusServiceUnavailable, glide.ErrCodeServiceUnavailable, "Service temporarily unavailable")
+}
@@ -0,0 +1,375 @@
+// Package glidetest provides an in-process fake of the Glide API for tests
+//
+// The fake implements every endpoint used by the SDK with realistic default
+// behavior, and lets tests script failures such as an ineligible carrier, an
+// expired session, 429 with Retry-After, or added latency:
+//
+//	server := glidetest.NewServer()
+//	defer server.Close()
+//
+//	server.Script(glidetest.PathSimSwapCheck, glidetest.RateLimited(time.Second))
+//	client := server.Client()
+package glidetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+)
+
+// Endpoint paths served by the fake
+const (
+	PathPrepare              = "/magic-auth/v2/auth/prepare"
+	PathVerifyPhoneNumber    = "/magic-auth/v2/auth/verify-phone-number"
+	PathGetPhoneNumber       = "/magic-auth/v2/auth/get-phone-number"
+	PathSimSwapCheck         = "/sim-swap/check"
+	PathSimSwapDate          = "/sim-swap/retrieve-date"
+	PathNumberVerify         = "/number-verify/verify"
+	PathKYCMatch             = "/kyc-match/match"
+	PathKYCAgeVerification   = "/kyc-age-verification/verify"
+	PathKYCFillIn            = "/kyc-fill-in/retrieve"
+	PathLocationVerification = "/location-verification/verify"
+	PathDeviceRoaming        = "/device-status/roaming"
+	PathDeviceConnectivity   = "/device-status/connectivity"
+	PathHealth               = glide.DefaultHealthCheckPath
+
+	// AnyPath scripts a scenario for every endpoint
+	AnyPath = "*"
+)
+
+const (
+	// DefaultAPIKey is the API key accepted by the fake unless WithAPIKey is used
+	DefaultAPIKey = "glidetest-api-key"
+
+	// DefaultPhoneNumber is returned by get-phone-number when the session has no phone number
+	DefaultPhoneNumber = "+14157400083"
+
+	// DefaultSessionTTL is how long prepared sessions stay valid
+	DefaultSessionTTL = 5 * time.Minute
+)
+
+// Request is a request received by the fake
+type Request struct {
+	Method         string
+	Path           string
+	Header         http.Header
+	Body           map[string]interface{}
+	APIKey         string
+	IdempotencyKey string
+	ReceivedAt     time.Time
+}
+
+// Option configures the fake server
+type Option func(*Server)
+
+// WithAPIKey sets the only API key the fake accepts
+func WithAPIKey(key string) Option {
+	return WithAPIKeys(key)
+}
+
+// WithAPIKeys sets the API keys the fake accepts, e.g. one per tenant
+// Server.Client uses the first one
+func WithAPIKeys(keys ...string) Option {
+	return func(s *Server) {
+		s.apiKeys = keys
+	}
+}
+
+// WithSessionTTL sets how long prepared sessions stay valid
+func WithSessionTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.sessionTTL = ttl
+	}
+}
+
+// Server is an in-process fake Glide API
+type Server struct {
+	*httptest.Server
+
+	sessionTTL time.Duration
+
+	mu        sync.Mutex
+	apiKeys   []string              // Accepted API keys
+	scripted  map[string][]Scenario // One-shot scenarios, consumed in order
+	always    map[string]*Scenario  // Persistent scenarios, used when nothing is scripted
+	sessions  map[string]session    // Prepared sessions by session key
+	responses map[string]recorded   // Responses by idempotency key, replayed for duplicates
+	requests  []Request
+	requestID int
+}
+
+// session is a prepared magic auth session
+type session struct {
+	useCase     string
+	phoneNumber string
+	expiresAt   time.Time
+}
+
+// recorded is a response kept for idempotent replay
+type recorded struct {
+	path   string
+	status int
+	header http.Header
+	body   []byte
+}
+
+// NewServer starts a fake Glide API server
+// The caller must call Close when finished
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		apiKeys:    []string{DefaultAPIKey},
+		sessionTTL: DefaultSessionTTL,
+		scripted:   make(map[string][]Scenario),
+		always:     make(map[string]*Scenario),
+		sessions:   make(map[string]session),
+		responses:  make(map[string]recorded),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns an SDK client pointed at the fake
+// Retries are disabled by default; opts are applied last and can re-enable them
+func (s *Server) Client(opts ...glide.Option) *glide.Client {
+	s.mu.Lock()
+	apiKey := ""
+	if len(s.apiKeys) > 0 {
+		apiKey = s.apiKeys[0]
+	}
+	s.mu.Unlock()
+
+	base := []glide.Option{
+		glide.WithAPIKey(apiKey),
+		glide.WithBaseURL(s.URL),
+		glide.WithRetry(0, 0),
+	}
+	return glide.New(append(base, opts...)...)
+}
+
+// Script queues one-shot scenarios for path, used in order by the next requests
+// Use AnyPath to apply them to whichever endpoint is called next
+func (s *Server) Script(path string, scenarios ...Scenario) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripted[path] = append(s.scripted[path], scenarios...)
+}
+
+// Always applies scenario to every request for path until Reset
+func (s *Server) Always(path string, scenario Scenario) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.always[path] = &scenario
+}
+
+// SetAPIKeys replaces the accepted API keys, e.g. to revoke a rotated key
+func (s *Server) SetAPIKeys(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKeys = keys
+}
+
+// ExpireSessions expires every prepared session
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]session)
+}
+
+// Reset clears scenarios, sessions, idempotent responses and recorded requests
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripted = make(map[string][]Scenario)
+	s.always = make(map[string]*Scenario)
+	s.sessions = make(map[string]session)
+	s.responses = make(map[string]recorded)
+	s.requests = nil
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// RequestCount returns the number of requests received for path
+func (s *Server) RequestCount(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, r := range s.requests {
+		if r.Path == path || path == AnyPath {
+			n++
+		}
+	}
+	return n
+}
+
+// serveHTTP records the request, applies scenarios and dispatches to the endpoint handler
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	raw, _ := io.ReadAll(r.Body)
+	var body map[string]interface{}
+	bodyErr := json.Unmarshal(raw, &body)
+
+	key := r.Header.Get("Idempotency-Key")
+	apiKey := r.URL.Query().Get("apikey")
+	s.mu.Lock()
+	s.requestID++
+	requestID := fmt.Sprintf("glidetest-%d", s.requestID)
+	s.requests = append(s.requests, Request{
+		Method:         r.Method,
+		Path:           r.URL.Path,
+		Header:         r.Header.Clone(),
+		Body:           body,
+		APIKey:         apiKey,
+		IdempotencyKey: key,
+		ReceivedAt:     time.Now(),
+	})
+	authorized := false
+	for _, accepted := range s.apiKeys {
+		authorized = authorized || apiKey == accepted
+	}
+	scenario := s.nextScenario(r.URL.Path)
+	replay, replayed := s.responses[key]
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if scenario != nil && scenario.Latency > 0 {
+		timer := time.NewTimer(scenario.Latency)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+
+	// Authentication comes first, like the real API
+	if !authorized {
+		w.Header().Set("X-Request-ID", requestID)
+		writeError(w, http.StatusUnauthorized, "", "Invalid API key")
+		return
+	}
+
+	// A retried call with the same idempotency key gets the original response under a new request ID
+	if key != "" && replayed && replay.path == r.URL.Path {
+		for name, values := range replay.header {
+			w.Header()[name] = values
+		}
+		w.Header().Set("X-Request-ID", requestID)
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(replay.status)
+		w.Write(replay.body)
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	if scenario != nil {
+		scenario.writeHeader(rec)
+	}
+	switch {
+	case scenario != nil && scenario.handled():
+		scenario.write(rec)
+	case r.URL.Path == PathHealth && r.Method == http.MethodGet:
+		rec.Write([]byte(`{"status":"ok"}`))
+	case r.Method != http.MethodPost:
+		writeError(rec, http.StatusMethodNotAllowed, glide.ErrCodeBadRequest, "Method not allowed")
+	case bodyErr != nil:
+		writeError(rec, http.StatusBadRequest, glide.ErrCodeBadRequest, "Request body must be a JSON object")
+	default:
+		handler, ok := s.handlers()[r.URL.Path]
+		if !ok {
+			writeError(rec, http.StatusNotFound, "NOT_FOUND", "Endpoint not found")
+			break
+		}
+		handler(rec, body)
+	}
+
+	// Only completed requests are replayed; throttled and failed ones may be retried
+	if key != "" && rec.Code < http.StatusInternalServerError && rec.Code != http.StatusTooManyRequests {
+		s.mu.Lock()
+		header := rec.Header().Clone()
+		header.Del("X-Request-ID")
+		s.responses[key] = recorded{path: r.URL.Path, status: rec.Code, header: header, body: rec.Body.Bytes()}
+		s.mu.Unlock()
+	}
+
+	for name, values := range rec.Header() {
+		w.Header()[name] = values
+	}
+	w.Header().Set("X-Request-ID", requestID)
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+}
+
+// nextScenario returns the scenario for a request, consuming one-shot scenarios
+// Must be called with s.mu held
+func (s *Server) nextScenario(path string) *Scenario {
+	persistent, ok := s.always[path]
+	if !ok {
+		persistent = s.always[AnyPath]
+	}
+	for _, key := range []string{path, AnyPath} {
+		if queue := s.scripted[key]; len(queue) > 0 {
+			scenario := queue[0]
+			s.scripted[key] = queue[1:]
+			// A latency-only scenario delays whatever would have been answered
+			if !scenario.handled() && persistent != nil {
+				scenario = persistent.delayed(scenario)
+			}
+			return &scenario
+		}
+	}
+	return persistent
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(v)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// writeError writes an error in the Glide API format
+// An empty code is replaced by the code the SDK reports for the status
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	if code == "" {
+		code = defaultErrorCode(status)
+	}
+	writeJSON(w, status, map[string]string{"code": code, "message": message})
+}
+
+// defaultErrorCode returns the public error code for an HTTP status
+// 401 and 403 have no public code and are reported as INTERNAL_SERVER_ERROR
+func defaultErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusMethodNotAllowed:
+		return glide.ErrCodeBadRequest
+	case http.StatusNotFound:
+		return glide.ErrCodeSessionNotFound
+	case http.StatusUnprocessableEntity:
+		return glide.ErrCodeUnprocessableEntity
+	case http.StatusTooManyRequests:
+		return glide.ErrCodeRateLimitExceeded
+	case http.StatusServiceUnavailable:
+		return glide.ErrCodeServiceUnavailable
+	default:
+		return glide.ErrCodeInternalServerError
+	}
+}
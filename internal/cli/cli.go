@@ -0,0 +1,213 @@
+// Package cli implements the glide command line tool for support and operations teams
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+)
+
+// Exit codes
+const (
+	ExitOK      = 0 // Every call succeeded
+	ExitFailure = 1 // At least one call failed or returned a negative result
+	ExitUsage   = 2 // Bad flags, arguments, input or configuration
+)
+
+// Output formats
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// App is the glide CLI with its I/O streams, so it can be driven from tests
+type App struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Options are applied after those derived from flags, e.g. glide.WithHTTPClient
+	Options []glide.Option
+}
+
+// Main runs the CLI with the process arguments and standard streams and returns the exit code
+func Main() int {
+	app := &App{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+	return app.Run(context.Background(), os.Args[1:])
+}
+
+// globalOptions are the flags accepted before or after the command
+type globalOptions struct {
+	apiKey      string
+	baseURL     string
+	configPath  string
+	output      string
+	input       string
+	region      string
+	timeout     time.Duration
+	concurrency int
+	logLevel    string
+	logFormat   string
+}
+
+// register adds the global flags to fs, using the current values as defaults
+// so that flags given before the command survive parsing the command's flags
+func (g *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.apiKey, "api-key", g.apiKey, "API key (default $GLIDE_API_KEY or the config file)")
+	fs.StringVar(&g.baseURL, "base-url", g.baseURL, "API base URL (default $GLIDE_BASE_URL or the config file)")
+	fs.StringVar(&g.configPath, "config", g.configPath, "YAML or JSON config file (default $GLIDE_CONFIG or <user config dir>/glide/config.yaml)")
+	fs.StringVar(&g.output, "output", g.output, "output format: table or json")
+	fs.StringVar(&g.input, "input", g.input, `CSV file with one call per row, "-" for stdin`)
+	fs.StringVar(&g.region, "default-region", g.region, "normalize phone numbers in national format for this region, e.g. US")
+	fs.DurationVar(&g.timeout, "timeout", g.timeout, "HTTP timeout per request (default from the config, 30s)")
+	fs.IntVar(&g.concurrency, "concurrency", g.concurrency, "number of calls in flight in batch mode")
+	fs.StringVar(&g.logLevel, "log-level", g.logLevel, "SDK log level, overrides $GLIDE_LOG_LEVEL (logs go to stderr)")
+	fs.StringVar(&g.logFormat, "log-format", g.logFormat, "SDK log format, overrides $GLIDE_LOG_FORMAT")
+}
+
+// errUsage reports bad invocations, after the message has been printed
+var errUsage = errors.New("usage error")
+
+// Run executes the command line args (without the program name) and returns the exit code
+func (a *App) Run(ctx context.Context, args []string) int {
+	g := &globalOptions{output: OutputTable, concurrency: 1}
+	fs := flag.NewFlagSet("glide", flag.ContinueOnError)
+	fs.SetOutput(a.Stderr)
+	g.register(fs)
+	fs.Usage = func() { a.usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	cmd, rest := findCommand(fs.Args())
+	if cmd == nil {
+		if fs.NArg() > 0 {
+			fmt.Fprintf(a.Stderr, "glide: unknown command %q\n\n", strings.Join(fs.Args(), " "))
+		}
+		a.usage(fs)
+		return ExitUsage
+	}
+
+	in, err := cmd.parse(a.Stderr, g, rest)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+	if g.output != OutputTable && g.output != OutputJSON {
+		fmt.Fprintf(a.Stderr, "glide: unknown output format %q (use table or json)\n", g.output)
+		return ExitUsage
+	}
+
+	var client *glide.Client
+	if !cmd.offline {
+		client, err = a.newClient(g)
+		if err != nil {
+			fmt.Fprintf(a.Stderr, "glide: %v\n", err)
+			return ExitUsage
+		}
+	}
+
+	if g.input == "" {
+		return a.runSingle(ctx, cmd, client, g, in)
+	}
+
+	rows, err := a.readBatch(g.input, cmd, in)
+	if err != nil {
+		fmt.Fprintf(a.Stderr, "glide: %v\n", err)
+		return ExitUsage
+	}
+	return a.runBatch(ctx, cmd, client, g, rows)
+}
+
+// newClient builds an SDK client from flags, environment and config file, in that order of precedence
+func (a *App) newClient(g *globalOptions) (*glide.Client, error) {
+	path := g.configPath
+	if path == "" && os.Getenv("GLIDE_CONFIG") == "" {
+		path = defaultConfigFile()
+	}
+	cfg, err := glide.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if g.apiKey == "" && cfg.APIKey == "" && cfg.Credentials == nil {
+		return nil, errors.New("no API key: use -api-key, set GLIDE_API_KEY or GLIDE_API_KEY_FILE, or add api_key to the config file")
+	}
+
+	opts := []glide.Option{
+		glide.WithConfig(cfg),
+		// Logs must not mix with results on stdout
+		glide.WithLogOutput(a.Stderr),
+	}
+	if g.apiKey != "" {
+		opts = append(opts, glide.WithAPIKey(g.apiKey))
+	}
+	if g.baseURL != "" {
+		opts = append(opts, glide.WithBaseURL(g.baseURL))
+	}
+	if g.timeout > 0 {
+		opts = append(opts, glide.WithTimeout(g.timeout))
+	}
+	if g.region != "" {
+		opts = append(opts, glide.WithPhoneNormalization(g.region))
+	}
+	if g.logLevel != "" {
+		opts = append(opts, glide.WithLogLevel(glide.ParseLogLevel(g.logLevel)))
+	}
+	if g.logFormat != "" {
+		opts = append(opts, glide.WithLogFormat(glide.ParseLogFormat(g.logFormat)))
+	}
+	opts = append(opts, a.Options...)
+	return glide.NewWithError(opts...)
+}
+
+// defaultConfigFile returns the first config file found in <user config dir>/glide, or ""
+func defaultConfigFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	for _, name := range []string{"config.yaml", "config.yml", "config.json"} {
+		path := filepath.Join(dir, "glide", name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// usage prints the command list and global flags
+func (a *App) usage(fs *flag.FlagSet) {
+	fmt.Fprintln(a.Stderr, "Usage: glide [flags] <command> [arguments] [command flags]")
+	fmt.Fprintln(a.Stderr)
+	fmt.Fprintln(a.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(a.Stderr, "  %-18s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(a.Stderr)
+	fmt.Fprintln(a.Stderr, "Flags (also accepted after the command):")
+	fs.PrintDefaults()
+	fmt.Fprintln(a.Stderr)
+	fmt.Fprintln(a.Stderr, `Run "glide <command> -h" for the command's arguments and CSV columns.`)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
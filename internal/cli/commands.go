@@ -0,0 +1,538 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/phonenumber"
+)
+
+// input holds the values of a command's fields for one call, keyed by field name
+type input map[string]string
+
+// field is a command input, settable as a positional argument, a flag or a CSV column
+type field struct {
+	name       string // Field name, also the CSV column; the flag uses dashes
+	usage      string
+	positional bool // Accepted as a positional argument, in order
+}
+
+// flagName returns the command flag for the field, e.g. max-age-hours
+func (f field) flagName() string {
+	return strings.ReplaceAll(f.name, "_", "-")
+}
+
+// result is the outcome of a call, printed as JSON or as table rows
+type result interface {
+	rows() [][]string // Table rows matching the command columns
+}
+
+// failure is implemented by results that represent a negative outcome, such as an invalid number
+type failure interface {
+	failed() bool
+}
+
+// command is a CLI command
+type command struct {
+	name        string // Space-separated words, e.g. "simswap check"
+	summary     string
+	fields      []field
+	columns     []string // Table header; the first column identifies the input
+	offline     bool     // Runs without an API key or network
+	keyOptional bool     // The first field may be replaced by other fields, e.g. mcc and mnc
+	run         func(ctx context.Context, client *glide.Client, in input) (result, error)
+}
+
+// key returns the input value shown in the first column when the call fails
+func (c *command) key(in input) string {
+	return in[c.fields[0].name]
+}
+
+// parse reads the command's positional arguments and flags, in any order, into an input
+// Global flags are accepted too and update g
+func (c *command) parse(stderr io.Writer, g *globalOptions, args []string) (input, error) {
+	fs := flag.NewFlagSet("glide "+c.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	values := make(map[string]*string, len(c.fields))
+	for _, f := range c.fields {
+		values[f.name] = fs.String(f.flagName(), "", f.usage)
+	}
+	g.register(fs)
+	fs.Usage = func() { c.usage(stderr, fs) }
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	in := make(input)
+	for name, value := range values {
+		if *value != "" {
+			in[name] = *value
+		}
+	}
+	i := 0
+	for _, f := range c.fields {
+		if f.positional && i < len(positional) {
+			in[f.name] = positional[i]
+			i++
+		}
+	}
+	if i < len(positional) {
+		fmt.Fprintf(stderr, "glide %s: unexpected argument %q\n", c.name, positional[i])
+		return nil, errUsage
+	}
+	if g.input == "" && in[c.fields[0].name] == "" && !c.keyOptional {
+		fmt.Fprintf(stderr, "glide %s: missing %s\n\n", c.name, c.fields[0].name)
+		c.usage(stderr, fs)
+		return nil, errUsage
+	}
+	return in, nil
+}
+
+// usage prints the command's arguments, flags and CSV columns
+func (c *command) usage(w io.Writer, fs *flag.FlagSet) {
+	var args, columns []string
+	for _, f := range c.fields {
+		if f.positional {
+			args = append(args, "<"+f.name+">")
+		}
+		columns = append(columns, f.name)
+	}
+	fmt.Fprintf(w, "Usage: glide %s %s [flags]\n\n%s\n\n", c.name, strings.Join(args, " "), c.summary)
+	fmt.Fprintf(w, "CSV columns for -input: %s\n\nFlags:\n", strings.Join(columns, ", "))
+	fs.PrintDefaults()
+}
+
+// findCommand matches the leading words of args against the commands
+func findCommand(args []string) (*command, []string) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) {
+			continue
+		}
+		matched := true
+		for i, word := range words {
+			if args[i] != word {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return cmd, args[len(words):]
+		}
+	}
+	return nil, nil
+}
+
+var phoneNumberField = field{name: "phone_number", usage: "phone number in E.164 format", positional: true}
+
+// commands lists the CLI commands in usage order
+var commands = []*command{
+	{
+		name:    "simswap check",
+		summary: "Check whether the SIM was swapped recently",
+		fields: []field{
+			phoneNumberField,
+			{name: "max_age_hours", usage: "look-back window in hours (default 24)"},
+		},
+		columns: []string{"PHONE_NUMBER", "SWAPPED", "SWAPPED_AT", "CHECKED_AT"},
+		run:     runSimSwapCheck,
+	},
+	{
+		name:    "simswap date",
+		summary: "Get the date of the last SIM swap",
+		fields:  []field{phoneNumberField},
+		columns: []string{"PHONE_NUMBER", "LAST_SWAP_DATE", "CHECKED_AT"},
+		run:     runSimSwapDate,
+	},
+	{
+		name:    "numberverify",
+		summary: "Verify that a phone number belongs to the device",
+		fields: []field{
+			phoneNumberField,
+			{name: "code", usage: "verification code"},
+		},
+		columns: []string{"PHONE_NUMBER", "VERIFIED", "CHECKED_AT"},
+		run:     runNumberVerify,
+	},
+	{
+		name:    "kyc match",
+		summary: "Match identity attributes against carrier records",
+		fields:  append([]field{phoneNumberField}, kycFields...),
+		columns: []string{"PHONE_NUMBER", "OVERALL_MATCH", "MATCHED", "NOT_MATCHED", "NOT_AVAILABLE"},
+		run:     runKYCMatch,
+	},
+	{
+		name:    "magicauth prepare",
+		summary: "Prepare a Magic Auth session and show the selected strategy",
+		fields: []field{
+			phoneNumberField,
+			{name: "use_case", usage: "verify or get (default verify)"},
+			{name: "mcc", usage: "mobile country code, instead of a phone number"},
+			{name: "mnc", usage: "mobile network code, instead of a phone number"},
+			{name: "user_agent", usage: "browser user agent used for strategy selection"},
+		},
+		columns:     []string{"PHONE_NUMBER", "USE_CASE", "STRATEGY", "SESSION_KEY", "TTL"},
+		keyOptional: true,
+		run:         runPrepare,
+	},
+	{
+		name:    "plmn lookup",
+		summary: "Look up carriers by MCC-MNC, phone number or country (offline)",
+		fields: []field{
+			{name: "query", usage: "MCC-MNC such as 310-260, a phone number or a country code", positional: true},
+			{name: "mcc", usage: "mobile country code"},
+			{name: "mnc", usage: "mobile network code"},
+		},
+		columns:     []string{"QUERY", "MCC", "MNC", "COUNTRY", "BRAND", "OPERATOR"},
+		offline:     true,
+		keyOptional: true,
+		run:         runPLMNLookup,
+	},
+	{
+		name:    "validate phone",
+		summary: "Parse and validate a phone number (offline)",
+		fields: []field{
+			phoneNumberField,
+			{name: "region", usage: "region for numbers in national format, e.g. US"},
+		},
+		columns: []string{"PHONE_NUMBER", "VALID", "E164", "REGION", "TYPE", "REASON"},
+		offline: true,
+		run:     runValidatePhone,
+	},
+}
+
+type simSwapCheckResult struct {
+	PhoneNumber string `json:"phone_number"`
+	*glide.SimSwapCheckResponse
+}
+
+func (r simSwapCheckResult) rows() [][]string {
+	return [][]string{{r.PhoneNumber, strconv.FormatBool(r.Swapped), formatTime(r.SwappedAt), formatTime(&r.CheckedAt)}}
+}
+
+func runSimSwapCheck(ctx context.Context, client *glide.Client, in input) (result, error) {
+	req := &glide.SimSwapCheckRequest{PhoneNumber: in["phone_number"]}
+	if value := in["max_age_hours"]; value != "" {
+		hours, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_age_hours %q", value)
+		}
+		req.MaxAge = hours
+	}
+	resp, err := client.SimSwap.Check(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return simSwapCheckResult{PhoneNumber: req.PhoneNumber, SimSwapCheckResponse: resp}, nil
+}
+
+type simSwapDateResult struct {
+	PhoneNumber string `json:"phone_number"`
+	*glide.SimSwapDateResponse
+}
+
+func (r simSwapDateResult) rows() [][]string {
+	return [][]string{{r.PhoneNumber, formatTime(r.LastSwapDate), formatTime(&r.CheckedAt)}}
+}
+
+func runSimSwapDate(ctx context.Context, client *glide.Client, in input) (result, error) {
+	resp, err := client.SimSwap.GetLastSwapDate(ctx, &glide.SimSwapDateRequest{PhoneNumber: in["phone_number"]})
+	if err != nil {
+		return nil, err
+	}
+	return simSwapDateResult{PhoneNumber: in["phone_number"], SimSwapDateResponse: resp}, nil
+}
+
+type numberVerifyResult struct {
+	PhoneNumber string `json:"phone_number"`
+	*glide.NumberVerifyResponse
+}
+
+func (r numberVerifyResult) rows() [][]string {
+	return [][]string{{r.PhoneNumber, strconv.FormatBool(r.Verified), formatTime(&r.CheckedAt)}}
+}
+
+func (r numberVerifyResult) failed() bool {
+	return !r.Verified
+}
+
+func runNumberVerify(ctx context.Context, client *glide.Client, in input) (result, error) {
+	resp, err := client.NumberVerify.Verify(ctx, &glide.NumberVerifyRequest{PhoneNumber: in["phone_number"], Code: in["code"]})
+	if err != nil {
+		return nil, err
+	}
+	return numberVerifyResult{PhoneNumber: in["phone_number"], NumberVerifyResponse: resp}, nil
+}
+
+// kycFields are the KYC match attributes; address fields are nested under "address" in the request
+var kycFields = []field{
+	{name: "name", usage: "full name"},
+	{name: "given_name", usage: "given name"},
+	{name: "family_name", usage: "family name"},
+	{name: "middle_names", usage: "middle names"},
+	{name: "family_name_at_birth", usage: "family name at birth"},
+	{name: "birth_date", usage: "birth date (YYYY-MM-DD)"},
+	{name: "gender", usage: "MALE, FEMALE or OTHER"},
+	{name: "nationality", usage: "nationality (ISO 3166-1 alpha-2)"},
+	{name: "email", usage: "email address"},
+	{name: "street", usage: "street name"},
+	{name: "house_number", usage: "house number"},
+	{name: "house_number_extension", usage: "house number extension"},
+	{name: "city", usage: "city"},
+	{name: "state", usage: "state"},
+	{name: "region", usage: "address region"},
+	{name: "postal_code", usage: "postal code"},
+	{name: "country", usage: "address country"},
+	{name: "id_document", usage: "identity document number"},
+	{name: "id_document_type", usage: "identity document type, e.g. passport"},
+	{name: "id_document_expiry_date", usage: "identity document expiry date (YYYY-MM-DD)"},
+}
+
+// kycAddressFields are the kycFields that belong to the address
+var kycAddressFields = map[string]bool{
+	"street": true, "house_number": true, "house_number_extension": true, "city": true,
+	"state": true, "region": true, "postal_code": true, "country": true,
+}
+
+type kycMatchResult struct {
+	PhoneNumber string `json:"phone_number"`
+	*glide.KYCMatchResponse
+}
+
+func (r kycMatchResult) rows() [][]string {
+	var matched, notMatched, notAvailable []string
+	for name, match := range r.MatchResults {
+		switch {
+		case match.Result == glide.KYCCheckNotAvailable:
+			notAvailable = append(notAvailable, name)
+		case match.Matched:
+			matched = append(matched, name)
+		default:
+			notMatched = append(notMatched, name)
+		}
+	}
+	return [][]string{{r.PhoneNumber, strconv.FormatBool(r.OverallMatch), joinSorted(matched), joinSorted(notMatched), joinSorted(notAvailable)}}
+}
+
+func (r kycMatchResult) failed() bool {
+	return !r.OverallMatch
+}
+
+// kycMatchRequest builds the request from the input through its JSON form, so fields map by their JSON names
+func kycMatchRequest(in input) (*glide.KYCMatchRequest, error) {
+	body := map[string]interface{}{"phone_number": in["phone_number"]}
+	address := map[string]interface{}{}
+	for _, f := range kycFields {
+		value := in[f.name]
+		switch {
+		case value == "":
+		case kycAddressFields[f.name]:
+			address[f.name] = value
+		case f.name == "gender":
+			body[f.name] = strings.ToUpper(value)
+		default:
+			body[f.name] = value
+		}
+	}
+	if len(address) > 0 {
+		body["address"] = address
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req := &glide.KYCMatchRequest{}
+	if err := json.Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func runKYCMatch(ctx context.Context, client *glide.Client, in input) (result, error) {
+	req, err := kycMatchRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.KYC.Match(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return kycMatchResult{PhoneNumber: in["phone_number"], KYCMatchResponse: resp}, nil
+}
+
+type prepareResult struct {
+	PhoneNumber string `json:"phone_number,omitempty"`
+	*glide.PrepareResponse
+	UseCase glide.UseCase `json:"use_case"`
+}
+
+func (r prepareResult) rows() [][]string {
+	ttl := ""
+	if r.TTL > 0 {
+		ttl = (time.Duration(r.TTL) * time.Second).String()
+	}
+	return [][]string{{r.PhoneNumber, string(r.UseCase), string(r.AuthenticationStrategy), r.Session.SessionKey, ttl}}
+}
+
+// parseUseCase accepts the API names and the short forms verify and get
+func parseUseCase(value string) (glide.UseCase, error) {
+	switch strings.ToLower(value) {
+	case "", "verify", strings.ToLower(string(glide.UseCaseVerifyPhoneNumber)):
+		return glide.UseCaseVerifyPhoneNumber, nil
+	case "get", strings.ToLower(string(glide.UseCaseGetPhoneNumber)):
+		return glide.UseCaseGetPhoneNumber, nil
+	default:
+		return "", fmt.Errorf("invalid use_case %q (use verify or get)", value)
+	}
+}
+
+func runPrepare(ctx context.Context, client *glide.Client, in input) (result, error) {
+	useCase, err := parseUseCase(in["use_case"])
+	if err != nil {
+		return nil, err
+	}
+	req := &glide.PrepareRequest{PhoneNumber: in["phone_number"], UseCase: useCase}
+	if in["mcc"] != "" || in["mnc"] != "" {
+		req.PLMN = &glide.PLMN{MCC: in["mcc"], MNC: in["mnc"]}
+	}
+	if in["user_agent"] != "" {
+		req.ClientInfo = &glide.ClientInfo{UserAgent: in["user_agent"]}
+	}
+	resp, err := client.MagicAuth.Prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return prepareResult{PhoneNumber: req.PhoneNumber, PrepareResponse: resp, UseCase: useCase}, nil
+}
+
+type plmnLookupResult struct {
+	Query    string           `json:"query"`
+	Networks []glide.PLMNInfo `json:"networks"`
+}
+
+func (r plmnLookupResult) rows() [][]string {
+	rows := make([][]string, len(r.Networks))
+	for i, n := range r.Networks {
+		rows[i] = []string{r.Query, n.MCC, n.MNC, n.CountryISO, n.Brand, n.Operator}
+	}
+	return rows
+}
+
+func runPLMNLookup(_ context.Context, _ *glide.Client, in input) (result, error) {
+	query := strings.TrimSpace(in["query"])
+	mcc, mnc := in["mcc"], in["mnc"]
+
+	var networks []glide.PLMNInfo
+	switch {
+	case mcc != "" || mnc != "":
+		query = mcc + "-" + mnc
+		if info, ok := glide.LookupPLMN(mcc, mnc); ok {
+			networks = append(networks, info)
+		}
+	case query == "":
+		return nil, errors.New("missing query, or mcc and mnc")
+	case strings.HasPrefix(query, "+"):
+		networks = glide.PLMNsForPhoneNumber(query)
+	case strings.ContainsAny(query, "-/"):
+		parts := strings.FieldsFunc(query, func(r rune) bool { return r == '-' || r == '/' })
+		if len(parts) == 2 {
+			if info, ok := glide.LookupPLMN(parts[0], parts[1]); ok {
+				networks = append(networks, info)
+			}
+		}
+	case isDigits(query) && (len(query) == 5 || len(query) == 6):
+		if info, ok := glide.LookupPLMN(query[:3], query[3:]); ok {
+			networks = append(networks, info)
+		}
+	default:
+		networks = glide.PLMNsForCountry(query)
+	}
+
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("no network found for %q", query)
+	}
+	return plmnLookupResult{Query: query, Networks: networks}, nil
+}
+
+type validatePhoneResult struct {
+	PhoneNumber string `json:"phone_number"`
+	Valid       bool   `json:"valid"`
+	E164        string `json:"e164,omitempty"`
+	Region      string `json:"region,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+func (r validatePhoneResult) rows() [][]string {
+	return [][]string{{r.PhoneNumber, strconv.FormatBool(r.Valid), r.E164, r.Region, r.Type, r.Reason}}
+}
+
+func (r validatePhoneResult) failed() bool {
+	return !r.Valid
+}
+
+func runValidatePhone(_ context.Context, _ *glide.Client, in input) (result, error) {
+	res := validatePhoneResult{PhoneNumber: in["phone_number"]}
+	parsed, err := phonenumber.Parse(res.PhoneNumber, in["region"])
+	if err != nil {
+		res.Reason = err.Error()
+		return res, nil
+	}
+	res.E164 = parsed.E164()
+	res.Region = parsed.Region
+	res.Type = parsed.Type.String()
+	if err := glide.ValidatePhoneNumber(res.E164); err != nil {
+		if glideErr, ok := err.(*glide.Error); ok {
+			res.Reason = glideErr.Message
+		} else {
+			res.Reason = err.Error()
+		}
+		return res, nil
+	}
+	res.Valid = true
+	return res, nil
+}
+
+// formatTime formats an optional time for tables
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func joinSorted(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
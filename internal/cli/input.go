@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// batchRow is one call read from batch input
+type batchRow struct {
+	line int // Line number in the input, for matching results to rows
+	in   input
+}
+
+// columnAliases map common CSV headers to field names
+var columnAliases = map[string]string{
+	"phone":  "phone_number",
+	"msisdn": "phone_number",
+	"number": "phone_number",
+}
+
+// readBatch reads CSV rows from path, or stdin for "-", into inputs for cmd
+//
+// The first row is a header when it names at least one of the command's fields; other
+// columns are ignored. Without a header, columns map to the positional fields in order,
+// so a plain list of phone numbers works. Values from command flags apply to every row
+// unless the row sets them.
+func (a *App) readBatch(path string, cmd *command, defaults input) ([]batchRow, error) {
+	var r io.Reader = a.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open input: %w", err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	known := make(map[string]bool, len(cmd.fields))
+	var columns []string
+	for _, f := range cmd.fields {
+		known[f.name] = true
+		if f.positional {
+			columns = append(columns, f.name)
+		}
+	}
+
+	var rows []batchRow
+	first := true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		if first {
+			first = false
+			if header, ok := parseHeader(record, known); ok {
+				columns = header
+				continue
+			}
+		}
+
+		in := make(input, len(defaults)+len(record))
+		for name, value := range defaults {
+			in[name] = value
+		}
+		empty := true
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if i >= len(columns) || columns[i] == "" || value == "" {
+				continue
+			}
+			in[columns[i]] = value
+			empty = false
+		}
+		if !empty {
+			rows = append(rows, batchRow{line: line, in: in})
+		}
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("input has no rows")
+	}
+	return rows, nil
+}
+
+// parseHeader returns the field name of each column, "" for unknown ones,
+// and whether the record is a header at all
+func parseHeader(record []string, known map[string]bool) ([]string, bool) {
+	columns := make([]string, len(record))
+	isHeader := false
+	for i, name := range record {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer("-", "_", " ", "_").Replace(name)
+		if alias, ok := columnAliases[name]; ok && known[alias] {
+			name = alias
+		}
+		if known[name] {
+			columns[i] = name
+			isHeader = true
+		}
+	}
+	return columns, isHeader
+}
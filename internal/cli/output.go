@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+)
+
+// outcome is the result of one call in a batch
+type outcome struct {
+	res result
+	err error
+}
+
+// batchRecord is a JSON Lines record in batch mode
+type batchRecord struct {
+	Line   int         `json:"line"`
+	Input  input       `json:"input"`
+	Result result      `json:"result,omitempty"`
+	Error  interface{} `json:"error,omitempty"`
+}
+
+// runSingle runs one call and prints its result, or the error on stderr
+func (a *App) runSingle(ctx context.Context, cmd *command, client *glide.Client, g *globalOptions, in input) int {
+	res, err := cmd.run(ctx, client, in)
+	if err != nil {
+		fmt.Fprintf(a.Stderr, "glide %s: %v\n", cmd.name, err)
+		return ExitFailure
+	}
+
+	if g.output == OutputJSON {
+		encoder := json.NewEncoder(a.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(res); err != nil {
+			fmt.Fprintf(a.Stderr, "glide: failed to write output: %v\n", err)
+			return ExitFailure
+		}
+	} else {
+		tw := newTable(a.Stdout, cmd.columns)
+		writeRows(tw, res.rows())
+		tw.Flush()
+	}
+	return exitCode(outcome{res: res})
+}
+
+// runBatch runs one call per row with up to g.concurrency calls in flight and prints the results in input order
+func (a *App) runBatch(ctx context.Context, cmd *command, client *glide.Client, g *globalOptions, rows []batchRow) int {
+	outcomes := make([]outcome, len(rows))
+	workers := g.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(rows)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res, err := cmd.run(ctx, client, rows[i].in)
+				outcomes[i] = outcome{res: res, err: err}
+			}
+		}()
+	}
+	for i := range rows {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	code := ExitOK
+	if g.output == OutputJSON {
+		encoder := json.NewEncoder(a.Stdout)
+		for i, o := range outcomes {
+			record := batchRecord{Line: rows[i].line, Input: rows[i].in, Result: o.res}
+			if o.err != nil {
+				record.Error = errorJSON(o.err)
+			}
+			if err := encoder.Encode(record); err != nil {
+				fmt.Fprintf(a.Stderr, "glide: failed to write output: %v\n", err)
+				return ExitFailure
+			}
+			code = max(code, exitCode(o))
+		}
+		return code
+	}
+
+	tw := newTable(a.Stdout, append(append([]string{"LINE"}, cmd.columns...), "ERROR"))
+	for i, o := range outcomes {
+		line := fmt.Sprint(rows[i].line)
+		if o.err != nil {
+			cells := make([]string, len(cmd.columns)+2)
+			cells[0], cells[1] = line, cmd.key(rows[i].in)
+			for c := 2; c < len(cells)-1; c++ {
+				cells[c] = "-"
+			}
+			cells[len(cells)-1] = o.err.Error()
+			writeRows(tw, [][]string{cells})
+		} else {
+			for _, row := range o.res.rows() {
+				writeRows(tw, [][]string{append(append([]string{line}, row...), "")})
+			}
+		}
+		code = max(code, exitCode(o))
+	}
+	tw.Flush()
+	return code
+}
+
+// exitCode returns ExitFailure for failed calls and negative results
+func exitCode(o outcome) int {
+	if o.err != nil {
+		return ExitFailure
+	}
+	if f, ok := o.res.(failure); ok && f.failed() {
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// errorJSON returns the JSON form of a call error; SDK errors keep their code, status and request ID
+func errorJSON(err error) interface{} {
+	if glideErr, ok := err.(*glide.Error); ok {
+		return glideErr
+	}
+	return map[string]string{"message": err.Error()}
+}
+
+// newTable starts an aligned table with the given header
+func newTable(w io.Writer, columns []string) *tabwriter.Writer {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	writeRows(tw, [][]string{columns})
+	return tw
+}
+
+func writeRows(tw *tabwriter.Writer, rows [][]string) {
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			// Keep each cell on one line so the columns stay aligned
+			cells[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(cell)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+}
@@ -0,0 +1,216 @@
+package phonenumber
+
+import (
+	"regexp"
+)
+
+// regionMetadata describes the numbering plan of a region
+type regionMetadata struct {
+	region         string
+	countryCode    int
+	nationalPrefix string // Trunk prefix dialled before national numbers, e.g. "0"
+	lengths        []int  // Valid national significant number lengths
+
+	// pattern matches valid national significant numbers (optional, lengths only if nil)
+	pattern *regexp.Regexp
+
+	// mobile matches mobile numbers, nil when mobile ranges are unknown
+	mobile *regexp.Regexp
+
+	// tollFree matches toll-free numbers (optional)
+	tollFree *regexp.Regexp
+
+	// mixedMobile is true when mobile and fixed-line numbers share ranges (e.g. NANP)
+	mixedMobile bool
+}
+
+// validLength checks if a national significant number has a valid length for the region
+func (m *regionMetadata) validLength(nsn string) bool {
+	for _, l := range m.lengths {
+		if len(nsn) == l {
+			return true
+		}
+	}
+	return false
+}
+
+// lengthRange returns a slice of lengths from lo to hi inclusive
+func lengthRange(lo, hi int) []int {
+	lengths := make([]int, 0, hi-lo+1)
+	for l := lo; l <= hi; l++ {
+		lengths = append(lengths, l)
+	}
+	return lengths
+}
+
+// regions holds the numbering plan metadata by ISO 3166-1 alpha-2 code
+var regions = map[string]*regionMetadata{
+	// North American Numbering Plan
+	"US": {region: "US", countryCode: 1, nationalPrefix: "1", lengths: []int{10},
+		pattern:     regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`),
+		tollFree:    regexp.MustCompile(`^8(00|33|44|55|66|77|88)`),
+		mixedMobile: true},
+	"CA": {region: "CA", countryCode: 1, nationalPrefix: "1", lengths: []int{10},
+		pattern:     regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`),
+		tollFree:    regexp.MustCompile(`^8(00|33|44|55|66|77|88)`),
+		mixedMobile: true},
+	"MX": {region: "MX", countryCode: 52, lengths: []int{10}, mixedMobile: true},
+
+	// Europe
+	"GB": {region: "GB", countryCode: 44, nationalPrefix: "0", lengths: []int{9, 10},
+		mobile:   regexp.MustCompile(`^7[1-57-9]\d{8}$`),
+		tollFree: regexp.MustCompile(`^80[08]`)},
+	"IE": {region: "IE", countryCode: 353, nationalPrefix: "0", lengths: lengthRange(7, 9),
+		mobile: regexp.MustCompile(`^8[3-9]\d{7}$`)},
+	"DE": {region: "DE", countryCode: 49, nationalPrefix: "0", lengths: lengthRange(6, 13),
+		mobile:   regexp.MustCompile(`^1[5-7]\d{8,9}$`),
+		tollFree: regexp.MustCompile(`^800`)},
+	"FR": {region: "FR", countryCode: 33, nationalPrefix: "0", lengths: []int{9},
+		mobile:   regexp.MustCompile(`^[67]\d{8}$`),
+		tollFree: regexp.MustCompile(`^80[0-5]`)},
+	"ES": {region: "ES", countryCode: 34, lengths: []int{9},
+		mobile:   regexp.MustCompile(`^(6\d|7[1-9])\d{7}$`),
+		tollFree: regexp.MustCompile(`^900`)},
+	"IT": {region: "IT", countryCode: 39, lengths: lengthRange(6, 11),
+		mobile:   regexp.MustCompile(`^3\d{8,9}$`),
+		tollFree: regexp.MustCompile(`^80[03]`)},
+	"PT": {region: "PT", countryCode: 351, lengths: []int{9},
+		mobile: regexp.MustCompile(`^9[1236]\d{7}$`)},
+	"NL": {region: "NL", countryCode: 31, nationalPrefix: "0", lengths: []int{9},
+		mobile:   regexp.MustCompile(`^6\d{8}$`),
+		tollFree: regexp.MustCompile(`^800`)},
+	"BE": {region: "BE", countryCode: 32, nationalPrefix: "0", lengths: []int{8, 9},
+		mobile: regexp.MustCompile(`^4[5-9]\d{7}$`)},
+	"CH": {region: "CH", countryCode: 41, nationalPrefix: "0", lengths: []int{9},
+		mobile: regexp.MustCompile(`^7[5-9]\d{7}$`)},
+	"AT": {region: "AT", countryCode: 43, nationalPrefix: "0", lengths: lengthRange(4, 13),
+		mobile: regexp.MustCompile(`^6[5-9]\d{5,11}$`)},
+	"SE": {region: "SE", countryCode: 46, nationalPrefix: "0", lengths: lengthRange(7, 10),
+		mobile: regexp.MustCompile(`^7[02369]\d{7}$`)},
+	"NO": {region: "NO", countryCode: 47, lengths: []int{8},
+		mobile: regexp.MustCompile(`^[49]\d{7}$`)},
+	"DK": {region: "DK", countryCode: 45, lengths: []int{8}, mixedMobile: true},
+	"FI": {region: "FI", countryCode: 358, nationalPrefix: "0", lengths: lengthRange(5, 12),
+		mobile: regexp.MustCompile(`^(4\d|50)\d{4,8}$`)},
+	"PL": {region: "PL", countryCode: 48, lengths: []int{9},
+		mobile: regexp.MustCompile(`^(45|5[0137]|6[069]|7[2389]|88)\d{7}$`)},
+	"CZ": {region: "CZ", countryCode: 420, lengths: []int{9},
+		mobile: regexp.MustCompile(`^[67]\d{8}$`)},
+	"HU": {region: "HU", countryCode: 36, nationalPrefix: "06", lengths: []int{8, 9},
+		mobile: regexp.MustCompile(`^(20|30|31|50|70)\d{7}$`)},
+	"RO": {region: "RO", countryCode: 40, nationalPrefix: "0", lengths: []int{9},
+		mobile: regexp.MustCompile(`^7\d{8}$`)},
+	"GR": {region: "GR", countryCode: 30, lengths: []int{10},
+		mobile: regexp.MustCompile(`^69\d{8}$`)},
+	"UA": {region: "UA", countryCode: 380, nationalPrefix: "0", lengths: []int{9},
+		mobile: regexp.MustCompile(`^(39|50|6[3678]|73|9[1-9])\d{7}$`)},
+	"TR": {region: "TR", countryCode: 90, nationalPrefix: "0", lengths: []int{10},
+		mobile: regexp.MustCompile(`^5\d{9}$`)},
+	"RU": {region: "RU", countryCode: 7, nationalPrefix: "8", lengths: []int{10},
+		mobile: regexp.MustCompile(`^9\d{9}$`)},
+	"KZ": {region: "KZ", countryCode: 7, nationalPrefix: "8", lengths: []int{10},
+		mobile: regexp.MustCompile(`^7\d{9}$`)},
+
+	// Middle East and Africa
+	"IL": {region: "IL", countryCode: 972, nationalPrefix: "0", lengths: []int{8, 9},
+		mobile: regexp.MustCompile(`^5\d{8}$`)},
+	"AE": {region: "AE", countryCode: 971, nationalPrefix: "0", lengths: []int{8, 9},
+		mobile: regexp.MustCompile(`^5[024-68]\d{7}$`)},
+	"SA": {region: "SA", countryCode: 966, nationalPrefix: "0", lengths: []int{9},
+		mobile: regexp.MustCompile(`^5\d{8}$`)},
+	"EG": {region: "EG", countryCode: 20, nationalPrefix: "0", lengths: lengthRange(8, 10),
+		mobile: regexp.MustCompile(`^1[0125]\d{8}$`)},
+	"ZA": {region: "ZA", countryCode: 27, nationalPrefix: "0", lengths: []int{9},
+		mobile: regexp.MustCompile(`^[678]\d{8}$`)},
+	"NG": {region: "NG", countryCode: 234, nationalPrefix: "0", lengths: lengthRange(8, 10),
+		mobile: regexp.MustCompile(`^[789][01]\d{8}$`)},
+	"KE": {region: "KE", countryCode: 254, nationalPrefix: "0", lengths: []int{9},
+		mobile: regexp.MustCompile(`^(7\d|1[01])\d{7}$`)},
+
+	// Asia Pacific
+	"IN": {region: "IN", countryCode: 91, nationalPrefix: "0", lengths: []int{10},
+		mobile: regexp.MustCompile(`^[6-9]\d{9}$`)},
+	"CN": {region: "CN", countryCode: 86, nationalPrefix: "0", lengths: lengthRange(7, 11),
+		mobile: regexp.MustCompile(`^1[3-9]\d{9}$`)},
+	"JP": {region: "JP", countryCode: 81, nationalPrefix: "0", lengths: []int{9, 10},
+		mobile: regexp.MustCompile(`^[789]0\d{8}$`)},
+	"KR": {region: "KR", countryCode: 82, nationalPrefix: "0", lengths: lengthRange(8, 10),
+		mobile: regexp.MustCompile(`^1[0-9]\d{7,8}$`)},
+	"HK": {region: "HK", countryCode: 852, lengths: []int{8},
+		mobile: regexp.MustCompile(`^[4-79]\d{7}$`)},
+	"SG": {region: "SG", countryCode: 65, lengths: []int{8},
+		mobile: regexp.MustCompile(`^[89]\d{7}$`)},
+	"PH": {region: "PH", countryCode: 63, nationalPrefix: "0", lengths: lengthRange(8, 10),
+		mobile: regexp.MustCompile(`^9\d{9}$`)},
+	"ID": {region: "ID", countryCode: 62, nationalPrefix: "0", lengths: lengthRange(7, 12),
+		mobile: regexp.MustCompile(`^8\d{8,11}$`)},
+	"TH": {region: "TH", countryCode: 66, nationalPrefix: "0", lengths: []int{8, 9},
+		mobile: regexp.MustCompile(`^[689]\d{8}$`)},
+	"VN": {region: "VN", countryCode: 84, nationalPrefix: "0", lengths: []int{9, 10},
+		mobile: regexp.MustCompile(`^[35789]\d{8}$`)},
+	"AU": {region: "AU", countryCode: 61, nationalPrefix: "0", lengths: []int{9},
+		mobile:   regexp.MustCompile(`^4\d{8}$`),
+		tollFree: regexp.MustCompile(`^180`)},
+	"NZ": {region: "NZ", countryCode: 64, nationalPrefix: "0", lengths: lengthRange(8, 10),
+		mobile: regexp.MustCompile(`^2\d{7,9}$`)},
+
+	// South America
+	"BR": {region: "BR", countryCode: 55, nationalPrefix: "0", lengths: []int{10, 11},
+		mobile: regexp.MustCompile(`^[1-9]{2}9\d{8}$`)},
+	"AR": {region: "AR", countryCode: 54, nationalPrefix: "0", lengths: []int{10, 11}},
+}
+
+// mainRegions maps calling codes to the region used when no better match is known
+var mainRegions = map[int]string{
+	1: "US",
+	7: "RU",
+}
+
+// canadianAreaCodes lists NANP area codes assigned to Canada
+var canadianAreaCodes = map[string]bool{
+	"204": true, "226": true, "236": true, "249": true, "250": true, "263": true,
+	"289": true, "306": true, "343": true, "354": true, "365": true, "367": true,
+	"368": true, "382": true, "403": true, "416": true, "418": true, "428": true,
+	"431": true, "437": true, "438": true, "450": true, "468": true, "474": true,
+	"506": true, "514": true, "519": true, "548": true, "579": true, "581": true,
+	"584": true, "587": true, "604": true, "613": true, "639": true, "647": true,
+	"672": true, "683": true, "705": true, "709": true, "742": true, "753": true,
+	"778": true, "780": true, "782": true, "807": true, "819": true, "825": true,
+	"867": true, "873": true, "879": true, "902": true, "905": true,
+}
+
+// regionsByCode indexes regions by calling code
+var regionsByCode = buildRegionsByCode()
+
+// buildRegionsByCode groups region metadata by calling code
+func buildRegionsByCode() map[int][]*regionMetadata {
+	index := make(map[int][]*regionMetadata)
+	for _, m := range regions {
+		index[m.countryCode] = append(index[m.countryCode], m)
+	}
+	return index
+}
+
+// regionForNumber picks the region for a national significant number within a calling code
+func regionForNumber(countryCode int, nsn string) *regionMetadata {
+	candidates := regionsByCode[countryCode]
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	switch countryCode {
+	case 1:
+		if len(nsn) >= 3 && canadianAreaCodes[nsn[:3]] {
+			return regions["CA"]
+		}
+	case 7:
+		if len(nsn) > 0 && (nsn[0] == '6' || nsn[0] == '7') {
+			return regions["KZ"]
+		}
+	}
+
+	if main, ok := mainRegions[countryCode]; ok {
+		return regions[main]
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// Package phonenumber parses phone numbers in national and international
+// formats and normalizes them to E.164.
+//
+// Basic usage:
+//
+//	num, err := phonenumber.Parse("(415) 555-2671", "US")
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(num.E164()) // +14155552671
+package phonenumber
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Errors returned by Parse
+var (
+	ErrEmpty              = errors.New("phone number is empty")
+	ErrInvalidCharacters  = errors.New("phone number contains invalid characters")
+	ErrMissingRegion      = errors.New("national number requires a default region")
+	ErrUnknownRegion      = errors.New("unknown region")
+	ErrUnknownCountryCode = errors.New("unknown country calling code")
+	ErrInvalidLength      = errors.New("invalid phone number length for region")
+	ErrInvalidNumber      = errors.New("invalid phone number for region")
+)
+
+// NumberType classifies a phone number
+type NumberType int
+
+const (
+	// NumberTypeUnknown is used when the metadata does not allow classification
+	NumberTypeUnknown NumberType = iota
+	// NumberTypeFixedLine is a landline number
+	NumberTypeFixedLine
+	// NumberTypeMobile is a mobile number
+	NumberTypeMobile
+	// NumberTypeFixedLineOrMobile is used where both share the same ranges (e.g. US)
+	NumberTypeFixedLineOrMobile
+	// NumberTypeTollFree is a toll-free number
+	NumberTypeTollFree
+)
+
+// String returns the string representation of a NumberType
+func (t NumberType) String() string {
+	switch t {
+	case NumberTypeFixedLine:
+		return "fixed_line"
+	case NumberTypeMobile:
+		return "mobile"
+	case NumberTypeFixedLineOrMobile:
+		return "fixed_line_or_mobile"
+	case NumberTypeTollFree:
+		return "toll_free"
+	default:
+		return "unknown"
+	}
+}
+
+// PhoneNumber is a parsed phone number
+type PhoneNumber struct {
+	CountryCode    int        // Country calling code, e.g. 44
+	NationalNumber string     // National significant number, without trunk prefix
+	Region         string     // ISO 3166-1 alpha-2 region, e.g. "GB"
+	Type           NumberType // Number type where metadata allows
+}
+
+// E164 returns the number in E.164 format
+func (n *PhoneNumber) E164() string {
+	return "+" + strconv.Itoa(n.CountryCode) + n.NationalNumber
+}
+
+// String returns the number in E.164 format
+func (n *PhoneNumber) String() string {
+	return n.E164()
+}
+
+// Parse parses a phone number in international or national format
+// defaultRegion (ISO 3166-1 alpha-2) is used for numbers without a country calling code
+func Parse(number, defaultRegion string) (*PhoneNumber, error) {
+	trimmed := strings.TrimSpace(number)
+	if trimmed == "" {
+		return nil, ErrEmpty
+	}
+
+	international := strings.HasPrefix(trimmed, "+")
+	digits, err := StripFormatting(trimmed)
+	if err != nil {
+		return nil, err
+	}
+
+	region := strings.ToUpper(strings.TrimSpace(defaultRegion))
+	var meta *regionMetadata
+	if region != "" {
+		meta = regions[region]
+		if meta == nil {
+			return nil, ErrUnknownRegion
+		}
+	}
+
+	// International dialling prefixes
+	if !international {
+		if rest, ok := stripIDD(digits, meta); ok {
+			digits = rest
+			international = true
+		}
+	}
+
+	if international {
+		return parseInternational(digits)
+	}
+
+	if meta == nil {
+		return nil, ErrMissingRegion
+	}
+	return parseNational(digits, meta)
+}
+
+// E164 parses a number and returns it in E.164 format
+func E164(number, defaultRegion string) (string, error) {
+	parsed, err := Parse(number, defaultRegion)
+	if err != nil {
+		return "", err
+	}
+	return parsed.E164(), nil
+}
+
+// StripFormatting removes common formatting characters and returns the digits
+// Spaces, dashes, dots, slashes, parentheses and a leading + are allowed
+func StripFormatting(number string) (string, error) {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(number) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ', r == '\u00a0', r == '-', r == '.', r == '/', r == '(', r == ')':
+		default:
+			return "", ErrInvalidCharacters
+		}
+	}
+	if b.Len() == 0 {
+		return "", ErrEmpty
+	}
+	return b.String(), nil
+}
+
+// RegionForCountryCode returns the main region for a calling code
+func RegionForCountryCode(countryCode int) string {
+	if main, ok := mainRegions[countryCode]; ok {
+		return main
+	}
+	if candidates := regionsByCode[countryCode]; len(candidates) > 0 {
+		return candidates[0].region
+	}
+	return ""
+}
+
+// CountryCodeForRegion returns the calling code for a region, or 0 if unknown
+func CountryCodeForRegion(region string) int {
+	if meta := regions[strings.ToUpper(region)]; meta != nil {
+		return meta.countryCode
+	}
+	return 0
+}
+
+// SupportedRegions returns the regions with numbering plan metadata
+func SupportedRegions() []string {
+	list := make([]string, 0, len(regions))
+	for region := range regions {
+		list = append(list, region)
+	}
+	return list
+}
+
+// stripIDD removes an international dialling prefix like 00 or 011
+func stripIDD(digits string, meta *regionMetadata) (string, bool) {
+	prefixes := []string{"00"}
+	if meta != nil {
+		switch meta.countryCode {
+		case 1:
+			prefixes = []string{"011"}
+		case 61:
+			prefixes = []string{"0011"}
+		case 81:
+			prefixes = []string{"010"}
+		}
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(digits, prefix) {
+			return digits[len(prefix):], true
+		}
+	}
+	return digits, false
+}
+
+// parseInternational splits the calling code from the national number
+func parseInternational(digits string) (*PhoneNumber, error) {
+	if len(digits) > 15 {
+		return nil, ErrInvalidLength
+	}
+
+	for size := 1; size <= 3 && size < len(digits); size++ {
+		code, _ := strconv.Atoi(digits[:size])
+		if _, known := regionsByCode[code]; !known {
+			continue
+		}
+		meta := regionForNumber(code, digits[size:])
+		// Tolerate a trunk prefix written after the calling code, e.g. +44 (0)20
+		nsn := stripNationalPrefix(digits[size:], meta)
+		return buildNumber(nsn, meta)
+	}
+
+	return nil, ErrUnknownCountryCode
+}
+
+// parseNational parses a number dialled within a region
+func parseNational(digits string, meta *regionMetadata) (*PhoneNumber, error) {
+	nsn := stripNationalPrefix(digits, meta)
+
+	// Regions sharing a calling code (e.g. US/CA) are resolved from the number itself
+	if resolved := regionForNumber(meta.countryCode, nsn); resolved != nil && resolved.countryCode == meta.countryCode {
+		meta = resolved
+	}
+	return buildNumber(nsn, meta)
+}
+
+// stripNationalPrefix removes the trunk prefix when the remaining number has a valid length
+func stripNationalPrefix(digits string, meta *regionMetadata) string {
+	prefix := meta.nationalPrefix
+	if prefix != "" && strings.HasPrefix(digits, prefix) && meta.validLength(digits[len(prefix):]) {
+		return digits[len(prefix):]
+	}
+	return digits
+}
+
+// buildNumber validates a national significant number and classifies it
+func buildNumber(nsn string, meta *regionMetadata) (*PhoneNumber, error) {
+	if !meta.validLength(nsn) {
+		return nil, ErrInvalidLength
+	}
+	if meta.pattern != nil && !meta.pattern.MatchString(nsn) {
+		return nil, ErrInvalidNumber
+	}
+
+	return &PhoneNumber{
+		CountryCode:    meta.countryCode,
+		NationalNumber: nsn,
+		Region:         meta.region,
+		Type:           classify(nsn, meta),
+	}, nil
+}
+
+// classify determines the number type from region metadata
+func classify(nsn string, meta *regionMetadata) NumberType {
+	switch {
+	case meta.tollFree != nil && meta.tollFree.MatchString(nsn):
+		return NumberTypeTollFree
+	case meta.mixedMobile:
+		return NumberTypeFixedLineOrMobile
+	case meta.mobile == nil:
+		return NumberTypeUnknown
+	case meta.mobile.MatchString(nsn):
+		return NumberTypeMobile
+	default:
+		return NumberTypeFixedLine
+	}
+}
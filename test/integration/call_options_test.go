@@ -0,0 +1,89 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallOptions(t *testing.T) {
+	ctx := context.Background()
+	req := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+
+	t.Run("should send per-call headers and idempotency key", func(t *testing.T) {
+		var headers http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = r.Header.Clone()
+			w.Write([]byte(`{"swapped":false,"checked_at":"2025-01-01T10:00:00Z"}`))
+		}))
+		defer server.Close()
+
+		client := glide.New(glide.WithAPIKey("test-key"), glide.WithBaseURL(server.URL))
+
+		_, err := client.SimSwap.Check(ctx, req,
+			glide.WithIdempotencyKey("login-123"),
+			glide.WithHeader("X-Tenant-ID", "acme"),
+			glide.WithHeader("Content-Type", "text/plain"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "login-123", headers.Get("Idempotency-Key"))
+		assert.Equal(t, "acme", headers.Get("X-Tenant-ID"))
+		assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	})
+
+	t.Run("should override retry count per call", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(2, time.Millisecond),
+		)
+
+		_, err := client.SimSwap.Check(ctx, req, glide.WithCallRetry(0))
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		atomic.StoreInt32(&calls, 0)
+		_, err = client.SimSwap.Check(ctx, req)
+		require.Error(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls), "client defaults apply without call options")
+	})
+
+	t.Run("should bound the whole call with the call timeout", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(3, 10*time.Millisecond),
+		)
+
+		start := time.Now()
+		_, err := client.SimSwap.Check(ctx, req, glide.WithCallTimeout(100*time.Millisecond))
+		require.Error(t, err)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "no retries after the deadline")
+	})
+}
@@ -0,0 +1,179 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCassetteFlow makes the calls recorded and replayed by TestCassette
+func runCassetteFlow(t *testing.T, client *glide.Client) (*glide.VerifyPhoneNumberResponse, *glide.KYCFillInResponse) {
+	ctx := context.Background()
+
+	prepared, err := client.MagicAuth.Prepare(ctx, &glide.PrepareRequest{
+		UseCase:     glide.UseCaseVerifyPhoneNumber,
+		PhoneNumber: testPhoneNumbers.TMobileValid,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, glide.AuthenticationStrategyTS43, prepared.AuthenticationStrategy)
+
+	verified, err := client.MagicAuth.VerifyPhoneNumber(ctx, &glide.VerifyPhoneNumberRequest{
+		Session:    prepared.Session,
+		Credential: "eyJhbGciOiJFUzI1NiJ9.secret-credential",
+	})
+	require.NoError(t, err)
+
+	identity, err := client.KYC.FillIn(ctx, &glide.KYCFillInRequest{
+		PhoneNumber: testPhoneNumbers.TMobileValid,
+		ConsentData: &glide.ConsentData{ConsentText: "I agree", PolicyLink: "https://example.com/privacy", PolicyText: "Privacy policy"},
+	})
+	require.NoError(t, err)
+	return verified, identity
+}
+
+func TestCassette(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "flow.json")
+	server := glidetest.NewServer(glidetest.WithAPIKey("secret-api-key"))
+
+	t.Run("should record sanitized interactions", func(t *testing.T) {
+		cassette, err := glide.NewCassette(path, glide.CassetteRecord, nil)
+		require.NoError(t, err)
+		client := glide.New(
+			glide.WithAPIKey("secret-api-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithHTTPClient(cassette.HTTPClient()),
+		)
+
+		verified, _ := runCassetteFlow(t, client)
+		assert.Equal(t, testPhoneNumbers.TMobileValid, verified.PhoneNumber, "recording returns live responses")
+		require.NoError(t, cassette.Close())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		content := string(data)
+		assert.NotContains(t, content, "secret-api-key")
+		assert.NotContains(t, content, "secret-credential")
+		assert.NotContains(t, content, testPhoneNumbers.TMobileValid)
+		assert.NotContains(t, content, "Jane")
+		assert.Contains(t, content, "+14157****")
+		assert.Contains(t, content, `"ts43"`)
+
+		var recorded struct {
+			Interactions []struct {
+				Request struct {
+					Body map[string]interface{} `json:"body"`
+				} `json:"request"`
+			} `json:"interactions"`
+		}
+		require.NoError(t, json.Unmarshal(data, &recorded))
+		require.Len(t, recorded.Interactions, 3)
+		assert.NotContains(t, recorded.Interactions[0].Request.Body, "nonce", "the random nonce is not recorded")
+	})
+	server.Close()
+
+	t.Run("should replay offline and ignore the nonce", func(t *testing.T) {
+		cassette, err := glide.NewCassette(path, glide.CassetteReplay, nil)
+		require.NoError(t, err)
+		client := glide.New(
+			glide.WithAPIKey("another-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithHTTPClient(cassette.HTTPClient()),
+		)
+
+		verified, identity := runCassetteFlow(t, client)
+		assert.True(t, verified.Verified)
+		assert.Equal(t, "+14157****", verified.PhoneNumber, "replayed responses are sanitized")
+		assert.True(t, identity.Identity.GivenName.Available)
+		assert.NotEqual(t, "Jane", identity.Identity.GivenName.Value)
+		require.NoError(t, cassette.Close())
+	})
+
+	t.Run("should fail loudly on unmatched calls", func(t *testing.T) {
+		cassette, err := glide.NewCassette(path, glide.CassetteReplay, nil)
+		require.NoError(t, err)
+		client := glide.New(
+			glide.WithBaseURL(server.URL),
+			glide.WithHTTPClient(cassette.HTTPClient()),
+			glide.WithRetry(3, 0),
+		)
+
+		_, err = client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.Error(t, err)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, glide.ErrCodeCassetteMismatch, glideErr.Code)
+		assert.Contains(t, glideErr.Message, "POST /sim-swap/check")
+		assert.Len(t, cassette.Unmatched(), 1, "mismatches are not retried")
+
+		closeErr := cassette.Close()
+		require.Error(t, closeErr)
+		assert.Contains(t, closeErr.Error(), "1 request(s) had no recording")
+	})
+
+	t.Run("should not replay a recording for a different request with the same masked body", func(t *testing.T) {
+		live := glidetest.NewServer()
+		defer live.Close()
+		hashPath := filepath.Join(t.TempDir(), "hash.json")
+		key := glide.WithCassetteMatchKey([]byte("ci-secret"))
+
+		recorder, err := glide.NewCassette(hashPath, glide.CassetteRecord, nil, key)
+		require.NoError(t, err)
+		client := live.Client(glide.WithHTTPClient(recorder.HTTPClient()))
+		_, err = client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: "+14155552671"})
+		require.NoError(t, err)
+		require.NoError(t, recorder.Close())
+
+		data, err := os.ReadFile(hashPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"body_hmac"`)
+		assert.NotContains(t, string(data), "ci-secret", "the key is never recorded")
+
+		cassette, err := glide.NewCassette(hashPath, glide.CassetteReplay, nil, key)
+		require.NoError(t, err)
+		client = glide.New(glide.WithBaseURL(live.URL), glide.WithHTTPClient(cassette.HTTPClient()))
+		_, err = client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: "+14155552672"})
+		requireGlideError(t, err, glide.ErrCodeCassetteMismatch, 0)
+		_, err = client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: "+14155552671"})
+		require.NoError(t, err)
+	})
+
+	t.Run("should not record a body digest without a match key", func(t *testing.T) {
+		live := glidetest.NewServer()
+		defer live.Close()
+		plainPath := filepath.Join(t.TempDir(), "plain.json")
+
+		recorder, err := glide.NewCassette(plainPath, glide.CassetteRecord, nil)
+		require.NoError(t, err)
+		client := live.Client(glide.WithHTTPClient(recorder.HTTPClient()))
+		_, err = client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: "+14155552671"})
+		require.NoError(t, err)
+		require.NoError(t, recorder.Close())
+
+		data, err := os.ReadFile(plainPath)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), `"body_hmac"`)
+
+		// Only the sanitized body is matched
+		cassette, err := glide.NewCassette(plainPath, glide.CassetteReplay, nil)
+		require.NoError(t, err)
+		client = glide.New(glide.WithBaseURL(live.URL), glide.WithHTTPClient(cassette.HTTPClient()))
+		_, err = client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: "+14155552672"})
+		require.NoError(t, err)
+	})
+
+	t.Run("should require the cassette file when replaying", func(t *testing.T) {
+		_, err := glide.NewCassette(filepath.Join(t.TempDir(), "missing.json"), glide.ParseCassetteMode(""), nil)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+		assert.Equal(t, glide.CassetteRecord, glide.ParseCassetteMode("Record"))
+	})
+}
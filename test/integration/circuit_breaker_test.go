@@ -0,0 +1,295 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger captures log messages by level
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) record(level, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, level+": "+msg)
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...glide.Field) { l.record("DEBUG", msg) }
+func (l *recordingLogger) Info(msg string, fields ...glide.Field)  { l.record("INFO", msg) }
+func (l *recordingLogger) Warn(msg string, fields ...glide.Field)  { l.record("WARN", msg) }
+func (l *recordingLogger) Error(msg string, fields ...glide.Field) { l.record("ERROR", msg) }
+
+func (l *recordingLogger) count(entry string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, m := range l.messages {
+		if m == entry {
+			n++
+		}
+	}
+	return n
+}
+
+func (l *recordingLogger) contains(entry string) bool {
+	return l.count(entry) > 0
+}
+
+// recordingMetrics captures circuit breaker metrics events
+type recordingMetrics struct {
+	mu          sync.Mutex
+	transitions []string
+	rejected    int
+	results     int
+}
+
+func (m *recordingMetrics) OnStateChange(operation string, from, to glide.CircuitState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transitions = append(m.transitions, fmt.Sprintf("%s:%s->%s", operation, from, to))
+}
+
+func (m *recordingMetrics) OnResult(operation string, success bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results++
+}
+
+func (m *recordingMetrics) OnRejected(operation string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejected++
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	var healthy atomic.Bool
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"swapped":false,"checked_at":"2025-01-01T10:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	metrics := &recordingMetrics{}
+	client := glide.New(
+		glide.WithAPIKey("test-key"),
+		glide.WithBaseURL(server.URL),
+		glide.WithRetry(0, 0),
+		glide.WithLogger(logger),
+		glide.WithCircuitBreaker(glide.CircuitBreakerConfig{
+			FailureRateThreshold: 0.5,
+			MinimumRequests:      3,
+			Cooldown:             100 * time.Millisecond,
+			Metrics:              metrics,
+		}),
+	)
+	req := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+
+	t.Run("should open after the failure rate threshold and fail fast", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := client.SimSwap.Check(ctx, req)
+			require.Error(t, err)
+		}
+		assert.Equal(t, glide.CircuitOpen, client.CircuitBreakerState("/sim-swap/check"))
+		assert.True(t, logger.contains("WARN: Circuit breaker opened"))
+
+		before := atomic.LoadInt32(&calls)
+		_, err := client.SimSwap.Check(ctx, req)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, glide.ErrCircuitOpen))
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, glide.ErrCodeCircuitOpen, glideErr.Code)
+		assert.Equal(t, "/sim-swap/check", glideErr.Details["operation"])
+		assert.Equal(t, before, atomic.LoadInt32(&calls), "open circuit must not reach the server")
+		assert.Equal(t, 1, metrics.rejected)
+	})
+
+	t.Run("should keep circuits per operation", func(t *testing.T) {
+		healthy.Store(true)
+		defer healthy.Store(false)
+
+		_, err := client.SimSwap.GetLastSwapDate(ctx, &glide.SimSwapDateRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		assert.False(t, errors.Is(err, glide.ErrCircuitOpen))
+		assert.Equal(t, glide.CircuitClosed, client.CircuitBreakerState("/sim-swap/retrieve-date"))
+	})
+
+	t.Run("should close again after a successful half-open probe", func(t *testing.T) {
+		time.Sleep(150 * time.Millisecond)
+		healthy.Store(true)
+
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, glide.CircuitClosed, client.CircuitBreakerState("/sim-swap/check"))
+		assert.True(t, logger.contains("INFO: Circuit breaker state changed"))
+
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		assert.Equal(t, []string{
+			"/sim-swap/check:closed->open",
+			"/sim-swap/check:open->half-open",
+			"/sim-swap/check:half-open->closed",
+		}, metrics.transitions)
+	})
+
+	t.Run("should reopen when the half-open probe fails", func(t *testing.T) {
+		healthy.Store(false)
+		for i := 0; i < 3; i++ {
+			client.SimSwap.Check(ctx, req)
+		}
+		require.Equal(t, glide.CircuitOpen, client.CircuitBreakerState("/sim-swap/check"))
+
+		time.Sleep(150 * time.Millisecond)
+		_, err := client.SimSwap.Check(ctx, req)
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, glide.ErrCircuitOpen), "probe reaches the server")
+		assert.Equal(t, glide.CircuitOpen, client.CircuitBreakerState("/sim-swap/check"))
+	})
+}
+
+// stateCheckingMetrics reads the circuit state from inside the metrics hooks
+type stateCheckingMetrics struct {
+	client *glide.Client
+	mu     sync.Mutex
+	states []string
+}
+
+func (m *stateCheckingMetrics) observe(operation, event string) {
+	state := m.client.CircuitBreakerState(operation)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states = append(m.states, event+":"+state.String())
+}
+
+func (m *stateCheckingMetrics) OnStateChange(operation string, from, to glide.CircuitState) {
+	m.observe(operation, "change")
+}
+
+func (m *stateCheckingMetrics) OnResult(operation string, success bool) {
+	m.observe(operation, "result")
+}
+
+func (m *stateCheckingMetrics) OnRejected(operation string) { m.observe(operation, "rejected") }
+
+func TestCircuitBreakerHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	t.Run("should let hooks call back into the client", func(t *testing.T) {
+		metrics := &stateCheckingMetrics{}
+		metrics.client = glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(0, 0),
+			glide.WithCircuitBreaker(glide.CircuitBreakerConfig{MinimumRequests: 1, Cooldown: time.Minute, Metrics: metrics}),
+		)
+
+		done := make(chan error, 1)
+		go func() {
+			metrics.client.SimSwap.Check(context.Background(), &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+			_, err := metrics.client.SimSwap.Check(context.Background(), &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			assert.True(t, errors.Is(err, glide.ErrCircuitOpen))
+		case <-time.After(5 * time.Second):
+			t.Fatal("a hook calling CircuitBreakerState deadlocked")
+		}
+
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		assert.Equal(t, []string{"result:open", "change:open", "rejected:open"}, metrics.states)
+	})
+
+	t.Run("should only match the circuit open sentinel", func(t *testing.T) {
+		assert.False(t, errors.Is(glide.NewError(glide.ErrCodeCircuitOpen, "Circuit breaker is open"), glide.ErrCircuitOpen))
+		assert.False(t, errors.Is(glide.NewError(glide.ErrCodeBadRequest, "a"), glide.NewError(glide.ErrCodeBadRequest, "b")),
+			"errors with the same code are not the same error")
+	})
+}
+
+func TestCircuitBreakerProbes(t *testing.T) {
+	// Requests sent with X-Hold wait at the server until their gate is opened
+	gates := map[string]chan struct{}{"closed": make(chan struct{}), "probe": make(chan struct{})}
+	arrived := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hold := r.Header.Get("X-Hold")
+		if gate, ok := gates[hold]; ok {
+			arrived <- hold
+			<-gate
+		}
+		if hold == "closed" {
+			w.Write([]byte(`{"swapped":false,"checked_at":"2025-01-01T10:00:00Z"}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := glide.New(
+		glide.WithAPIKey("test-key"),
+		glide.WithBaseURL(server.URL),
+		glide.WithRetry(0, 0),
+		glide.WithCircuitBreaker(glide.CircuitBreakerConfig{MinimumRequests: 2, Cooldown: 50 * time.Millisecond}),
+	)
+	ctx := context.Background()
+	req := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+	operation := "/sim-swap/check"
+
+	check := func(hold string) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := client.SimSwap.Check(ctx, req, glide.WithHeader("X-Hold", hold))
+			done <- err
+		}()
+		require.Equal(t, hold, <-arrived)
+		return done
+	}
+
+	t.Run("should only let half-open probes change the state", func(t *testing.T) {
+		slow := check("closed")
+		for i := 0; i < 2; i++ {
+			client.SimSwap.Check(ctx, req)
+		}
+		require.Equal(t, glide.CircuitOpen, client.CircuitBreakerState(operation))
+
+		time.Sleep(80 * time.Millisecond)
+		probe := check("probe")
+		require.Equal(t, glide.CircuitHalfOpen, client.CircuitBreakerState(operation))
+
+		// A request admitted while closed neither closes the circuit nor frees the probe slot
+		close(gates["closed"])
+		require.NoError(t, <-slow)
+		assert.Equal(t, glide.CircuitHalfOpen, client.CircuitBreakerState(operation))
+		_, err := client.SimSwap.Check(ctx, req)
+		assert.True(t, errors.Is(err, glide.ErrCircuitOpen), "the probe slot is still taken")
+
+		close(gates["probe"])
+		require.Error(t, <-probe)
+		assert.Equal(t, glide.CircuitOpen, client.CircuitBreakerState(operation))
+	})
+}
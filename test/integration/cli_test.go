@@ -0,0 +1,154 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/GlideIdentity/glide-be-sdk-go/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCLI runs the glide CLI and returns its exit code, stdout and stderr
+func runCLI(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	app := &cli.App{
+		Stdin:   strings.NewReader(stdin),
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+		Options: []glide.Option{glide.WithRetry(0, 0)},
+	}
+	code := app.Run(context.Background(), args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCLI(t *testing.T) {
+	server := glidetest.NewServer()
+	t.Cleanup(server.Close)
+	t.Setenv("GLIDE_API_KEY", "")
+	t.Setenv("GLIDE_CONFIG", "")
+	t.Setenv("GLIDE_LOG_LEVEL", "")
+	t.Setenv("GLIDE_LOG_FORMAT", "")
+	api := []string{"-api-key", glidetest.DefaultAPIKey, "-base-url", server.URL}
+
+	t.Run("should print a table by default", func(t *testing.T) {
+		code, stdout, stderr := runCLI(t, "", append(api, "simswap", "check", testPhoneNumbers.TMobileValid)...)
+		require.Equal(t, cli.ExitOK, code, stderr)
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{"PHONE_NUMBER", "SWAPPED", "SWAPPED_AT", "CHECKED_AT"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{testPhoneNumbers.TMobileValid, "false", "-"}, strings.Fields(lines[1])[:3])
+	})
+
+	t.Run("should keep JSON output clean when SDK logging is on", func(t *testing.T) {
+		t.Setenv("GLIDE_LOG_LEVEL", "debug")
+		t.Setenv("GLIDE_LOG_FORMAT", "pretty")
+
+		// Flags are accepted after the command and its arguments
+		code, stdout, stderr := runCLI(t, "", append([]string{"simswap", "check", testPhoneNumbers.TMobileValid, "-output", "json"}, api...)...)
+		require.Equal(t, cli.ExitOK, code, stderr)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &result), stdout)
+		assert.Equal(t, false, result["swapped"])
+		assert.Contains(t, stderr, "SimSwap CHECK REQUEST", "logs go to stderr in the configured format")
+		assert.NotContains(t, stderr, glidetest.DefaultAPIKey)
+	})
+
+	t.Run("should run a CSV batch and report failures per row", func(t *testing.T) {
+		input := "name,phone\nvalid," + testPhoneNumbers.TMobileValid + "\nforeign," + testPhoneNumbers.NonEligible + "\n"
+		code, stdout, stderr := runCLI(t, input, append(api, "-input", "-", "-output", "json", "-concurrency", "2", "magicauth", "prepare")...)
+		assert.Equal(t, cli.ExitFailure, code, stderr)
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, 2)
+		var ok, failed struct {
+			Line   int                    `json:"line"`
+			Input  map[string]string      `json:"input"`
+			Result map[string]interface{} `json:"result"`
+			Error  *glide.Error           `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &ok))
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &failed))
+
+		assert.Equal(t, 2, ok.Line)
+		assert.Equal(t, string(glide.AuthenticationStrategyTS43), ok.Result["authentication_strategy"])
+		assert.Nil(t, ok.Error)
+
+		assert.Equal(t, 3, failed.Line)
+		assert.Equal(t, testPhoneNumbers.NonEligible, failed.Input["phone_number"])
+		require.NotNil(t, failed.Error)
+		assert.Equal(t, glide.ErrCodeCarrierNotEligible, failed.Error.Code)
+		assert.NotEmpty(t, failed.Error.RequestID)
+	})
+
+	t.Run("should read plain lists of numbers as a table", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "numbers.csv")
+		require.NoError(t, os.WriteFile(path, []byte(testPhoneNumbers.TMobileValid+"\n"+testPhoneNumbers.TMobileValid+"\n"), 0o600))
+
+		code, stdout, stderr := runCLI(t, "", append(api, "simswap", "date", "-input", path)...)
+		require.Equal(t, cli.ExitOK, code, stderr)
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "LINE", strings.Fields(lines[0])[0])
+		assert.Equal(t, 2, server.RequestCount(glidetest.PathSimSwapDate))
+	})
+
+	t.Run("should take the API key from flag, environment, then config file", func(t *testing.T) {
+		config := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(config,
+			[]byte(`{"api_key": "`+glidetest.DefaultAPIKey+`", "base_url": "`+server.URL+`"}`), 0o600))
+		args := []string{"-config", config, "simswap", "check", testPhoneNumbers.TMobileValid}
+
+		code, _, stderr := runCLI(t, "", args...)
+		assert.Equal(t, cli.ExitOK, code, stderr)
+
+		t.Setenv("GLIDE_API_KEY", "wrong-key")
+		code, _, stderr = runCLI(t, "", args...)
+		assert.Equal(t, cli.ExitFailure, code)
+		assert.Contains(t, stderr, "Invalid API key")
+
+		code, _, stderr = runCLI(t, "", append([]string{"-api-key", glidetest.DefaultAPIKey}, args...)...)
+		assert.Equal(t, cli.ExitOK, code, stderr)
+
+		t.Setenv("GLIDE_API_KEY", "")
+		code, _, stderr = runCLI(t, "", "-config", filepath.Join(t.TempDir(), "missing.json"), "simswap", "check", testPhoneNumbers.TMobileValid)
+		assert.Equal(t, cli.ExitUsage, code)
+		assert.Contains(t, stderr, "failed to read config")
+	})
+
+	t.Run("should run offline commands without an API key", func(t *testing.T) {
+		code, stdout, stderr := runCLI(t, "", "plmn", "lookup", testPLMN.TMobileUS.MCC+"-"+testPLMN.TMobileUS.MNC)
+		require.Equal(t, cli.ExitOK, code, stderr)
+		assert.Contains(t, stdout, "T-Mobile US")
+
+		code, stdout, _ = runCLI(t, "", "-output", "json", "validate", "phone", "(415) 740-0083", "-region", "US")
+		assert.Equal(t, cli.ExitOK, code)
+		assert.Contains(t, stdout, `"e164": "+14157400083"`)
+
+		code, stdout, _ = runCLI(t, "", "validate", "phone", "12345")
+		assert.Equal(t, cli.ExitFailure, code, "invalid numbers fail the command")
+		assert.Contains(t, stdout, "false")
+	})
+
+	t.Run("should reject bad invocations", func(t *testing.T) {
+		code, _, stderr := runCLI(t, "", "simswap", "swap")
+		assert.Equal(t, cli.ExitUsage, code)
+		assert.Contains(t, stderr, `unknown command "simswap swap"`)
+
+		code, _, stderr = runCLI(t, "", append(api, "simswap", "check")...)
+		assert.Equal(t, cli.ExitUsage, code)
+		assert.Contains(t, stderr, "missing phone_number")
+
+		code, _, _ = runCLI(t, "", "-output", "xml", "plmn", "lookup", "US")
+		assert.Equal(t, cli.ExitUsage, code)
+	})
+}
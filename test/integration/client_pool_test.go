@@ -0,0 +1,265 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tenantDirectory is a TenantCredentialProvider backed by a map, counting lookups
+type tenantDirectory struct {
+	mu      sync.Mutex
+	tenants map[string]glide.TenantCredentials
+	lookups map[string]int
+	err     error
+}
+
+func newTenantDirectory(tenants map[string]glide.TenantCredentials) *tenantDirectory {
+	return &tenantDirectory{tenants: tenants, lookups: make(map[string]int)}
+}
+
+func (d *tenantDirectory) Credentials(_ context.Context, tenantID string) (glide.TenantCredentials, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lookups[tenantID]++
+	if d.err != nil {
+		return glide.TenantCredentials{}, d.err
+	}
+	creds, ok := d.tenants[tenantID]
+	if !ok {
+		return glide.TenantCredentials{}, errors.New("unknown tenant " + tenantID)
+	}
+	return creds, nil
+}
+
+func (d *tenantDirectory) setAPIKey(tenantID, apiKey string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	creds := d.tenants[tenantID]
+	creds.APIKey = apiKey
+	d.tenants[tenantID] = creds
+}
+
+func (d *tenantDirectory) lookupCount(tenantID string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lookups[tenantID]
+}
+
+// countingTransport counts the requests sent through it
+type countingTransport struct {
+	requests int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.requests, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientPool(t *testing.T) {
+	ctx := context.Background()
+	req := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+
+	newPool := func(t *testing.T, directory *tenantDirectory, opts ...glide.Option) (*glide.ClientPool, *glidetest.Server, *countingTransport) {
+		server := glidetest.NewServer(glidetest.WithAPIKeys("key-a", "key-b"))
+		t.Cleanup(server.Close)
+		transport := &countingTransport{}
+		pool := glide.NewClientPool(glide.ClientPoolConfig{
+			Provider:  directory,
+			Options:   append([]glide.Option{glide.WithBaseURL(server.URL), glide.WithRetry(0, 0)}, opts...),
+			Transport: transport,
+		})
+		return pool, server, transport
+	}
+
+	t.Run("should build each tenant client once and share the transport", func(t *testing.T) {
+		directory := newTenantDirectory(map[string]glide.TenantCredentials{
+			"merchant-a": {APIKey: "key-a"},
+			"merchant-b": {APIKey: "key-b"},
+		})
+		pool, server, transport := newPool(t, directory)
+
+		clients := make([]*glide.Client, 10)
+		var wg sync.WaitGroup
+		for i := range clients {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				client, err := pool.Client(ctx, "merchant-a")
+				assert.NoError(t, err)
+				clients[i] = client
+			}(i)
+		}
+		wg.Wait()
+		for _, client := range clients {
+			assert.Same(t, clients[0], client)
+		}
+		assert.Equal(t, 1, directory.lookupCount("merchant-a"))
+
+		clientB, err := pool.Client(ctx, "merchant-b")
+		require.NoError(t, err)
+		_, err = clients[0].SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		_, err = clientB.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+
+		requests := server.Requests()
+		require.Len(t, requests, 2)
+		assert.Equal(t, "key-a", requests[0].APIKey)
+		assert.Equal(t, "key-b", requests[1].APIKey)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&transport.requests))
+		assert.Equal(t, []string{"merchant-a", "merchant-b"}, pool.Tenants())
+	})
+
+	t.Run("should isolate rate limits and circuit breakers per tenant", func(t *testing.T) {
+		directory := newTenantDirectory(map[string]glide.TenantCredentials{
+			"merchant-a": {APIKey: "key-a", Options: []glide.Option{glide.WithRateLimit(1, time.Hour)}},
+			"merchant-b": {APIKey: "key-b"},
+		})
+		pool, server, _ := newPool(t, directory, glide.WithCircuitBreaker(glide.CircuitBreakerConfig{MinimumRequests: 2}))
+		clientA, err := pool.Client(ctx, "merchant-a")
+		require.NoError(t, err)
+		clientB, err := pool.Client(ctx, "merchant-b")
+		require.NoError(t, err)
+
+		server.Script(glidetest.PathSimSwapCheck, glidetest.ServiceUnavailable(), glidetest.ServiceUnavailable())
+		_, err = clientB.SimSwap.Check(ctx, req)
+		require.Error(t, err)
+		_, err = clientB.SimSwap.Check(ctx, req)
+		require.Error(t, err)
+		assert.Equal(t, glide.CircuitOpen, clientB.CircuitBreakerState(glidetest.PathSimSwapCheck))
+		assert.Equal(t, glide.CircuitClosed, clientA.CircuitBreakerState(glidetest.PathSimSwapCheck))
+
+		_, err = clientA.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		_, err = clientA.SimSwap.Check(ctx, req, glide.WithCallTimeout(20*time.Millisecond))
+		assertRateLimited(t, err)
+	})
+
+	t.Run("should rotate keys and keep the tenant's rate limit state", func(t *testing.T) {
+		directory := newTenantDirectory(map[string]glide.TenantCredentials{
+			"merchant-a": {APIKey: "key-a", Options: []glide.Option{glide.WithRateLimit(1, time.Hour)}},
+		})
+		pool, server, _ := newPool(t, directory)
+		old, err := pool.Client(ctx, "merchant-a")
+		require.NoError(t, err)
+		_, err = old.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+
+		server.SetAPIKeys("key-a2")
+		directory.setAPIKey("merchant-a", "key-a2")
+		rotated, err := pool.Rotate(ctx, "merchant-a")
+		require.NoError(t, err)
+		assert.NotSame(t, old, rotated)
+
+		current, err := pool.Client(ctx, "merchant-a")
+		require.NoError(t, err)
+		assert.Same(t, rotated, current)
+
+		_, err = rotated.SimSwap.Check(ctx, req, glide.WithCallTimeout(20*time.Millisecond))
+		assertRateLimited(t, err)
+	})
+
+	t.Run("should pick up rotated keys after the refresh interval", func(t *testing.T) {
+		directory := newTenantDirectory(map[string]glide.TenantCredentials{"merchant-a": {APIKey: "key-a"}})
+		server := glidetest.NewServer(glidetest.WithAPIKeys("key-a", "key-a2"))
+		t.Cleanup(server.Close)
+		pool := glide.NewClientPool(glide.ClientPoolConfig{
+			Provider:        directory,
+			Options:         []glide.Option{glide.WithBaseURL(server.URL), glide.WithRetry(0, 0)},
+			RefreshInterval: 20 * time.Millisecond,
+		})
+
+		first, err := pool.Client(ctx, "merchant-a")
+		require.NoError(t, err)
+		time.Sleep(30 * time.Millisecond)
+		same, err := pool.Client(ctx, "merchant-a")
+		require.NoError(t, err)
+		assert.Same(t, first, same, "an unchanged key keeps the client")
+		assert.Equal(t, 2, directory.lookupCount("merchant-a"))
+
+		directory.setAPIKey("merchant-a", "key-a2")
+		time.Sleep(30 * time.Millisecond)
+		rotated, err := pool.Client(ctx, "merchant-a")
+		require.NoError(t, err)
+		assert.NotSame(t, first, rotated)
+		_, err = rotated.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "key-a2", server.Requests()[0].APIKey)
+
+		directory.err = errors.New("secret store unavailable")
+		time.Sleep(30 * time.Millisecond)
+		kept, err := pool.Client(ctx, "merchant-a")
+		require.NoError(t, err, "a failed refresh keeps the cached client")
+		assert.Same(t, rotated, kept)
+	})
+
+	t.Run("should report unknown tenants and invalid tenant settings", func(t *testing.T) {
+		directory := newTenantDirectory(map[string]glide.TenantCredentials{
+			"merchant-a": {APIKey: "key-a", Options: []glide.Option{glide.WithRateLimit(0, time.Second)}},
+		})
+		pool, _, _ := newPool(t, directory)
+
+		_, err := pool.Client(ctx, "merchant-z")
+		assert.EqualError(t, err, "unknown tenant merchant-z")
+
+		_, err = pool.Client(ctx, "merchant-a")
+		assert.Equal(t, []string{"rate_limit.rate"}, violationFields(t, err))
+
+		_, err = pool.Client(ctx, "")
+		requireGlideError(t, err, glide.ErrCodeMissingParameters, 0)
+		assert.Empty(t, pool.Tenants())
+	})
+
+	t.Run("should not block other tenants while listing during a slow lookup", func(t *testing.T) {
+		directory := newTenantDirectory(map[string]glide.TenantCredentials{"merchant-a": {APIKey: "key-a"}})
+		entered, release := make(chan struct{}), make(chan struct{})
+		provider := glide.TenantCredentialProviderFunc(func(ctx context.Context, tenantID string) (glide.TenantCredentials, error) {
+			if tenantID == "merchant-slow" {
+				close(entered)
+				<-release
+				return glide.TenantCredentials{}, errors.New("unknown tenant " + tenantID)
+			}
+			return directory.Credentials(ctx, tenantID)
+		})
+		pool := glide.NewClientPool(glide.ClientPoolConfig{Provider: provider})
+		_, err := pool.Client(ctx, "merchant-a")
+		require.NoError(t, err)
+
+		slowDone := make(chan error, 1)
+		go func() {
+			_, err := pool.Client(ctx, "merchant-slow")
+			slowDone <- err
+		}()
+		<-entered
+		listed := make(chan []string, 1)
+		go func() { listed <- pool.Tenants() }()
+		time.Sleep(20 * time.Millisecond)
+
+		cached := make(chan error, 1)
+		go func() {
+			_, err := pool.Client(ctx, "merchant-a")
+			cached <- err
+		}()
+		select {
+		case err := <-cached:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("a cached tenant was blocked by another tenant's lookup")
+		}
+
+		close(release)
+		assert.EqualError(t, <-slowDone, "unknown tenant merchant-slow")
+		assert.Equal(t, []string{"merchant-a"}, <-listed)
+		assert.Equal(t, []string{"merchant-a"}, pool.Tenants())
+	})
+}
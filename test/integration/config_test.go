@@ -0,0 +1,152 @@
+package integration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile writes a config file with the given name to a temporary directory
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// violationFields returns the fields of the violations carried by a validation error
+func violationFields(t *testing.T, err error) []string {
+	t.Helper()
+	glideErr := requireGlideError(t, err, glide.ErrCodeValidationError, 0)
+	var fields []string
+	for _, violation := range glideErr.Violations() {
+		fields = append(fields, violation.Field)
+	}
+	return fields
+}
+
+func TestLoadConfig(t *testing.T) {
+	for _, name := range []string{"GLIDE_CONFIG", "GLIDE_API_KEY", "GLIDE_BASE_URL", "GLIDE_TIMEOUT",
+		"GLIDE_RETRY_COUNT", "GLIDE_DEBUG", "GLIDE_LOG_LEVEL", "GLIDE_LOG_FORMAT", "GLIDE_RATE_LIMIT_ENABLED"} {
+		t.Setenv(name, "")
+	}
+
+	yamlConfig := `
+api_key: file-key
+base_url: https://eu.api.glideidentity.app
+timeout: 10s
+retry_count: 5
+rate_limit:
+  rate: 100
+  period: 1m
+  operations:
+    /kyc:
+      rate: 10
+      period: 1s
+circuit_breaker:
+  cooldown: 45s
+kyc_hashing: true
+kyc_normalize: true
+log_format: json
+`
+
+	t.Run("should read nested YAML settings", func(t *testing.T) {
+		cfg, err := glide.LoadConfig(writeConfigFile(t, "glide.yaml", yamlConfig))
+		require.NoError(t, err)
+
+		assert.Equal(t, "file-key", cfg.APIKey)
+		assert.Equal(t, "https://eu.api.glideidentity.app", cfg.BaseURL)
+		assert.Equal(t, 10*time.Second, cfg.Timeout)
+		assert.Equal(t, 5, cfg.RetryCount)
+		assert.Equal(t, time.Second, cfg.RetryDelay, "unset values keep their defaults")
+		assert.True(t, cfg.RateLimitEnabled)
+		assert.Equal(t, 100, cfg.RateLimitRate)
+		assert.Equal(t, time.Minute, cfg.RateLimitPeriod)
+		assert.Equal(t, glide.RateLimit{Rate: 10, Period: time.Second}, cfg.OperationRateLimits["/kyc"])
+		require.NotNil(t, cfg.CircuitBreaker)
+		assert.Equal(t, 45*time.Second, cfg.CircuitBreaker.Cooldown)
+		assert.True(t, cfg.KYCHashing)
+		assert.True(t, cfg.KYCNormalize)
+		assert.Equal(t, glide.LogFormatJSON, cfg.LogFormat)
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("should let the environment override the file and options override both", func(t *testing.T) {
+		path := writeConfigFile(t, "glide.json", `{"api_key": "file-key", "timeout": "10s", "rate_limit": {"rate": 1000000, "period": "1h"}}`)
+		t.Setenv("GLIDE_CONFIG", path)
+		t.Setenv("GLIDE_TIMEOUT", "2s")
+		t.Setenv("GLIDE_RATE_LIMIT_ENABLED", "false")
+		t.Setenv("GLIDE_LOG_LEVEL", "warn")
+
+		cfg, err := glide.LoadConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, "file-key", cfg.APIKey)
+		assert.Equal(t, 2*time.Second, cfg.Timeout)
+		assert.Equal(t, 1000000, cfg.RateLimitRate)
+		assert.False(t, cfg.RateLimitEnabled)
+		assert.Equal(t, glide.LogLevelWarn, cfg.LogLevel)
+
+		server := glidetest.NewServer(glidetest.WithAPIKey("option-key"))
+		t.Cleanup(server.Close)
+		client, err := glide.NewWithError(glide.WithConfig(cfg), glide.WithAPIKey("option-key"), glide.WithBaseURL(server.URL))
+		require.NoError(t, err)
+		_, err = client.SimSwap.Check(context.Background(), &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.NoError(t, err)
+		assert.Equal(t, "file-key", cfg.APIKey, "options do not modify the loaded config")
+	})
+
+	t.Run("should report unknown keys and malformed values together", func(t *testing.T) {
+		t.Setenv("GLIDE_RETRY_COUNT", "three")
+		_, err := glide.LoadConfig(writeConfigFile(t, "glide.yml", "timeout: 30\nretries: 3\nlog_format: xml\n"))
+		assert.ElementsMatch(t, []string{"timeout", "log_format", "retries", "GLIDE_RETRY_COUNT"}, violationFields(t, err))
+		assert.Contains(t, err.Error(), `invalid duration "30"`)
+	})
+
+	t.Run("should fail on missing or unsupported files", func(t *testing.T) {
+		_, err := glide.LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+
+		_, err = glide.LoadConfig(writeConfigFile(t, "glide.toml", "api_key = 'x'"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported config file")
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Run("should reject invalid settings instead of building a client", func(t *testing.T) {
+		client, err := glide.NewWithError(
+			glide.WithBaseURL("api.glideidentity.app"),
+			glide.WithTimeout(-time.Second),
+			glide.WithRateLimit(0, time.Second),
+			glide.WithCircuitBreaker(glide.CircuitBreakerConfig{FailureRateThreshold: 1.5}),
+		)
+		assert.Nil(t, client)
+		assert.ElementsMatch(t, []string{"api_key", "base_url", "timeout", "rate_limit.rate", "circuit_breaker.failure_rate_threshold"},
+			violationFields(t, err))
+	})
+
+	t.Run("should accept operation limits without a global limit", func(t *testing.T) {
+		_, err := glide.NewWithError(glide.WithAPIKey("key"), glide.WithOperationRateLimit("/kyc", 10, time.Second))
+		require.NoError(t, err)
+
+		_, err = glide.NewWithError(glide.WithAPIKey("key"), glide.WithOperationRateLimit("/kyc", 0, time.Second))
+		assert.Equal(t, []string{"rate_limit.operations./kyc"}, violationFields(t, err))
+	})
+
+	t.Run("should check the default region when normalizing", func(t *testing.T) {
+		_, err := glide.NewWithError(glide.WithAPIKey("key"), glide.WithPhoneNormalization("XX"))
+		assert.Equal(t, []string{"default_region"}, violationFields(t, err))
+
+		client, err := glide.NewWithError(glide.WithAPIKey("key"), glide.WithPhoneNormalization("GB"))
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+}
@@ -0,0 +1,167 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialProvider(t *testing.T) {
+	ctx := context.Background()
+	req := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+
+	newServerClient := func(t *testing.T, provider glide.CredentialProvider, keys ...string) (*glidetest.Server, *glide.Client) {
+		server := glidetest.NewServer(glidetest.WithAPIKeys(keys...))
+		t.Cleanup(server.Close)
+		client, err := glide.NewWithError(
+			glide.WithCredentialProvider(provider),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(0, 0),
+		)
+		require.NoError(t, err)
+		return server, client
+	}
+
+	t.Run("should use a swapped key on the next request", func(t *testing.T) {
+		provider := glide.NewStaticCredentialProvider("key-old")
+		server, client := newServerClient(t, provider, "key-old", "key-new")
+
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		provider.SetAPIKey("key-new")
+		_, err = client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+
+		requests := server.Requests()
+		require.Len(t, requests, 2)
+		assert.Equal(t, "key-old", requests[0].APIKey)
+		assert.Equal(t, "key-new", requests[1].APIKey)
+	})
+
+	t.Run("should swap keys safely while requests are running", func(t *testing.T) {
+		provider := glide.NewStaticCredentialProvider("key-old")
+		server, client := newServerClient(t, provider, "key-old", "key-new")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if i == 10 {
+					provider.SetAPIKey("key-new")
+				}
+				_, err := client.SimSwap.Check(ctx, req)
+				assert.NoError(t, err)
+			}(i)
+		}
+		wg.Wait()
+		assert.Len(t, server.Requests(), 20)
+	})
+
+	t.Run("should retry once with the new key after a 401", func(t *testing.T) {
+		path := writeConfigFile(t, "api-key", "key-old\n")
+		provider, err := glide.NewFileCredentialProvider(path, time.Hour)
+		require.NoError(t, err)
+		server, client := newServerClient(t, provider, "key-old")
+
+		_, err = client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+
+		// The key is rotated: the file is updated and the old key is revoked
+		require.NoError(t, os.WriteFile(path, []byte("key-new\n"), 0o600))
+		server.SetAPIKeys("key-new")
+
+		var meta glide.ResponseMeta
+		_, err = client.SimSwap.Check(ctx, req, glide.WithResponseMeta(&meta))
+		require.NoError(t, err)
+		assert.Equal(t, 2, meta.Attempts)
+
+		requests := server.Requests()
+		require.Len(t, requests, 3)
+		assert.Equal(t, "key-old", requests[1].APIKey)
+		assert.Equal(t, "key-new", requests[2].APIKey)
+	})
+
+	t.Run("should not retry a 401 when the key did not change", func(t *testing.T) {
+		server, client := newServerClient(t, glide.NewStaticCredentialProvider("revoked"), "key-new")
+
+		_, err := client.SimSwap.Check(ctx, req)
+		glideErr := requireGlideError(t, err, glide.ErrCodeInternalServerError, 401)
+		assert.Equal(t, "Invalid API key", glideErr.Message)
+		assert.Len(t, server.Requests(), 1)
+	})
+
+	t.Run("should read the key from the environment on every request", func(t *testing.T) {
+		t.Setenv("TENANT_GLIDE_KEY", "key-old")
+		server, client := newServerClient(t, glide.EnvCredentialProvider{Name: "TENANT_GLIDE_KEY"}, "key-old", "key-new")
+
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		t.Setenv("TENANT_GLIDE_KEY", "key-new")
+		_, err = client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "key-new", server.Requests()[1].APIKey)
+
+		t.Setenv("TENANT_GLIDE_KEY", "")
+		_, err = client.SimSwap.Check(ctx, req)
+		requireGlideError(t, err, glide.ErrCodeInternalServerError, 0)
+		assert.Len(t, server.Requests(), 2, "no request is sent without a key")
+	})
+
+	t.Run("should keep the last key when the file cannot be re-read", func(t *testing.T) {
+		path := writeConfigFile(t, "api-key", "key-old")
+		provider, err := glide.NewFileCredentialProvider(path, time.Millisecond)
+		require.NoError(t, err)
+
+		require.NoError(t, os.Remove(path))
+		time.Sleep(5 * time.Millisecond)
+		apiKey, err := provider.APIKey(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "key-old", apiKey)
+		assert.ErrorIs(t, provider.Refresh(ctx), os.ErrNotExist)
+
+		_, err = glide.NewFileCredentialProvider(writeConfigFile(t, "empty", "\n"), 0)
+		assert.ErrorContains(t, err, "file is empty")
+	})
+
+	t.Run("should accept a custom provider", func(t *testing.T) {
+		calls := 0
+		provider := glide.CredentialProviderFunc(func(context.Context) (string, error) {
+			calls++
+			if calls > 1 {
+				return "", errors.New("vault sealed")
+			}
+			return "key-old", nil
+		})
+		_, client := newServerClient(t, provider, "key-old")
+
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		_, err = client.SimSwap.Check(ctx, req)
+		glideErr := requireGlideError(t, err, glide.ErrCodeInternalServerError, 0)
+		assert.NotContains(t, glideErr.Message, "vault")
+	})
+
+	t.Run("should load a key file from the configuration", func(t *testing.T) {
+		t.Setenv("GLIDE_CONFIG", "")
+		t.Setenv("GLIDE_API_KEY", "")
+		t.Setenv("GLIDE_API_KEY_FILE", writeConfigFile(t, "api-key", "key-old"))
+		cfg, err := glide.LoadConfig("")
+		require.NoError(t, err)
+		require.NotNil(t, cfg.Credentials)
+		require.NoError(t, cfg.Validate(), "a key file replaces api_key")
+
+		t.Setenv("GLIDE_API_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+		_, err = glide.LoadConfig("")
+		assert.Equal(t, []string{"GLIDE_API_KEY_FILE"}, violationFields(t, err))
+	})
+}
@@ -0,0 +1,84 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceStatus(t *testing.T) {
+	responses := map[string]string{
+		"/device-status/roaming":      `{"roaming":true,"country_code":262,"country_name":["DE","AT"],"last_status_time":"2025-01-01T10:00:00Z","checked_at":"2025-01-01T10:05:00Z"}`,
+		"/device-status/connectivity": `{"connectivity_status":"CONNECTED_SMS","checked_at":"2025-01-01T10:05:00Z"}`,
+	}
+	var received []map[string]interface{}
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received = append(received, body)
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(responses[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	client := glide.New(
+		glide.WithAPIKey("test-key"),
+		glide.WithBaseURL(server.URL),
+		glide.WithRetry(0, 0),
+	)
+	ctx := context.Background()
+
+	t.Run("should decode the roaming status", func(t *testing.T) {
+		resp, err := client.DeviceStatus.GetRoamingStatus(ctx, &glide.DeviceStatusRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.NoError(t, err)
+		assert.True(t, resp.Roaming)
+		assert.Equal(t, 262, resp.CountryCode)
+		assert.Equal(t, []string{"DE", "AT"}, resp.CountryName)
+		require.NotNil(t, resp.LastStatusTime)
+		assert.Equal(t, time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), *resp.LastStatusTime)
+		assert.Equal(t, time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC), resp.CheckedAt)
+
+		assert.Equal(t, "/device-status/roaming", paths[len(paths)-1])
+		assert.Equal(t, map[string]interface{}{"phone_number": testPhoneNumbers.TMobileValid}, received[len(received)-1])
+	})
+
+	t.Run("should decode the connectivity status", func(t *testing.T) {
+		resp, err := client.DeviceStatus.GetConnectivityStatus(ctx, &glide.DeviceStatusRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.NoError(t, err)
+		assert.Equal(t, glide.ConnectivityStatusConnectedSMS, resp.ConnectivityStatus)
+		assert.Nil(t, resp.LastStatusTime)
+		assert.Equal(t, "/device-status/connectivity", paths[len(paths)-1])
+	})
+
+	t.Run("should reject invalid phone numbers locally", func(t *testing.T) {
+		sent := len(received)
+
+		_, err := client.DeviceStatus.GetRoamingStatus(ctx, &glide.DeviceStatusRequest{})
+		requireGlideError(t, err, glide.ErrCodeMissingParameters, 0)
+
+		_, err = client.DeviceStatus.GetConnectivityStatus(ctx, &glide.DeviceStatusRequest{PhoneNumber: "4157400083"})
+		requireGlideError(t, err, glide.ErrCodeValidationError, 0)
+
+		assert.Len(t, received, sent, "invalid requests are not sent")
+	})
+
+	t.Run("should normalize formatted numbers when enabled", func(t *testing.T) {
+		normalizing := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithPhoneNormalization("US"),
+		)
+		_, err := normalizing.DeviceStatus.GetConnectivityStatus(ctx, &glide.DeviceStatusRequest{PhoneNumber: "(415) 740-0083"})
+		require.NoError(t, err)
+		assert.Equal(t, testPhoneNumbers.TMobileValid, received[len(received)-1]["phone_number"])
+	})
+}
@@ -0,0 +1,246 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkStatuses returns the status of every check in a report, keyed by name and endpoint
+func checkStatuses(report *glide.DiagnosticReport) map[string]glide.DiagnosticStatus {
+	statuses := make(map[string]glide.DiagnosticStatus)
+	for _, check := range report.Checks {
+		key := check.Name
+		if check.Endpoint != "" && check.Name != glide.CheckAuth && check.Name != glide.CheckClock {
+			key += " " + check.Endpoint
+		}
+		statuses[key] = check.Status
+	}
+	return statuses
+}
+
+func TestDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	server := glidetest.NewServer()
+	t.Cleanup(server.Close)
+
+	t.Run("should report a healthy setup", func(t *testing.T) {
+		client := server.Client()
+		require.NoError(t, client.Ping(ctx))
+
+		report := client.Diagnose(ctx)
+		assert.True(t, report.Healthy())
+		assert.Equal(t, map[string]glide.DiagnosticStatus{
+			glide.CheckConfig:                      glide.DiagnosticOK,
+			glide.CheckDNS + " " + server.URL:      glide.DiagnosticSkipped,
+			glide.CheckTLS + " " + server.URL:      glide.DiagnosticSkipped,
+			glide.CheckEndpoint + " " + server.URL: glide.DiagnosticOK,
+			glide.CheckAuth:                        glide.DiagnosticOK,
+			glide.CheckClock:                       glide.DiagnosticOK,
+		}, checkStatuses(report))
+		assert.NotEmpty(t, report.RequestID)
+		assert.Less(t, report.ClockSkew.Abs(), 2*time.Second)
+		assert.Zero(t, server.RequestCount(glidetest.PathSimSwapCheck), "diagnostics make no API calls")
+
+		encoded, err := json.Marshal(report)
+		require.NoError(t, err)
+		assert.Contains(t, string(encoded), `"status":"ok"`)
+	})
+
+	t.Run("should check TLS with the client's transport settings", func(t *testing.T) {
+		tlsServer := httptest.NewTLSServer(server.Config.Handler)
+		t.Cleanup(tlsServer.Close)
+		client := glide.New(
+			glide.WithAPIKey(glidetest.DefaultAPIKey),
+			glide.WithBaseURL(tlsServer.URL),
+			glide.WithHTTPClient(tlsServer.Client()),
+		)
+
+		report := client.Diagnose(ctx)
+		require.True(t, report.Healthy(), report.Checks)
+		tlsCheck := report.Check(glide.CheckTLS)
+		require.NotNil(t, tlsCheck)
+		assert.Equal(t, glide.DiagnosticOK, tlsCheck.Status)
+		assert.Contains(t, tlsCheck.Message, "TLS 1.3")
+
+		// Without the test CA the handshake fails
+		untrusted := glide.New(glide.WithAPIKey(glidetest.DefaultAPIKey), glide.WithBaseURL(tlsServer.URL))
+		report = untrusted.Diagnose(ctx)
+		assert.False(t, report.Healthy())
+		assert.Equal(t, glide.DiagnosticFailed, report.Check(glide.CheckTLS).Status)
+		assert.Contains(t, report.Check(glide.CheckTLS).Message, "certificate")
+		assert.Nil(t, report.Check(glide.CheckEndpoint), "the probe is not sent after a failed handshake")
+	})
+
+	t.Run("should report a rejected API key", func(t *testing.T) {
+		client := glide.New(glide.WithAPIKey("revoked-key"), glide.WithBaseURL(server.URL))
+		requireGlideError(t, client.Ping(ctx), glide.ErrCodeInternalServerError, http.StatusUnauthorized)
+
+		report := client.Diagnose(ctx)
+		assert.False(t, report.Healthy())
+		assert.Equal(t, glide.DiagnosticOK, report.Check(glide.CheckEndpoint).Status)
+		assert.Equal(t, glide.DiagnosticFailed, report.Check(glide.CheckAuth).Status)
+		assert.Empty(t, report.RequestID)
+	})
+
+	t.Run("should report an unreachable endpoint without leaking the key", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		client := glide.New(glide.WithAPIKey("secret-key"), glide.WithBaseURL(closed.URL))
+		requireGlideError(t, client.Ping(ctx), glide.ErrCodeServiceUnavailable, 0)
+
+		report := client.Diagnose(ctx)
+		assert.False(t, report.Healthy())
+		assert.Equal(t, glide.DiagnosticFailed, report.Check(glide.CheckEndpoint).Status)
+		assert.Equal(t, glide.DiagnosticSkipped, report.Check(glide.CheckAuth).Status)
+		assert.Equal(t, glide.DiagnosticSkipped, report.Check(glide.CheckClock).Status)
+		for _, check := range report.Checks {
+			assert.NotContains(t, check.Message, "secret-key")
+		}
+	})
+
+	t.Run("should report configuration problems", func(t *testing.T) {
+		client := glide.New(glide.WithAPIKey(""), glide.WithBaseURL(server.URL))
+		report := client.Diagnose(ctx)
+		assert.False(t, report.Healthy())
+		assert.Equal(t, glide.DiagnosticFailed, report.Check(glide.CheckConfig).Status)
+		assert.Contains(t, report.Check(glide.CheckConfig).Message, "API key is required")
+	})
+
+	t.Run("should warn about clock skew", func(t *testing.T) {
+		ahead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Date", time.Now().Add(2*time.Minute).UTC().Format(http.TimeFormat))
+			server.Config.Handler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(ahead.Close)
+
+		report := glide.New(glide.WithAPIKey(glidetest.DefaultAPIKey), glide.WithBaseURL(ahead.URL)).Diagnose(ctx)
+		assert.True(t, report.Healthy(), "clock skew is a warning")
+		assert.Equal(t, glide.DiagnosticWarning, report.Check(glide.CheckClock).Status)
+		assert.InDelta(t, 2*time.Minute, report.ClockSkew, float64(2*time.Second))
+	})
+
+	t.Run("should only warn about a failing region while another one works", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		client := glide.New(
+			glide.WithAPIKey(glidetest.DefaultAPIKey),
+			glide.WithEndpoints(glide.EndpointPolicyFailover,
+				glide.Endpoint{URL: closed.URL, Region: "us"},
+				glide.Endpoint{URL: server.URL, Region: "eu"},
+			),
+		)
+
+		report := client.Diagnose(ctx)
+		assert.True(t, report.Healthy())
+		statuses := checkStatuses(report)
+		assert.Equal(t, glide.DiagnosticWarning, statuses[glide.CheckEndpoint+" "+closed.URL])
+		assert.Equal(t, glide.DiagnosticOK, statuses[glide.CheckEndpoint+" "+server.URL])
+		assert.Equal(t, server.URL, report.Check(glide.CheckAuth).Endpoint)
+	})
+
+	t.Run("should fail DNS resolution for unknown hosts", func(t *testing.T) {
+		client := glide.New(glide.WithAPIKey(glidetest.DefaultAPIKey), glide.WithBaseURL("https://glide.invalid"))
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		report := client.Diagnose(ctx)
+		assert.False(t, report.Healthy())
+		dns := report.Check(glide.CheckDNS)
+		require.NotNil(t, dns)
+		assert.Equal(t, glide.DiagnosticFailed, dns.Status)
+		assert.Contains(t, dns.Message, "glide.invalid")
+	})
+
+	t.Run("should probe without creating sessions and reuse recent reports", func(t *testing.T) {
+		local := glidetest.NewServer()
+		t.Cleanup(local.Close)
+		client := local.Client()
+
+		require.NoError(t, client.Ping(ctx))
+		report := client.Diagnose(ctx)
+		require.True(t, report.Healthy())
+		requests := local.Requests()
+		require.Len(t, requests, 2)
+		for _, request := range requests {
+			assert.Equal(t, http.MethodGet, request.Method, "the probe must not create a session")
+			assert.Equal(t, glidetest.PathHealth, request.Path)
+		}
+
+		report.Checks[0].Status = glide.DiagnosticFailed
+		again := client.Diagnose(ctx)
+		assert.True(t, again.Healthy(), "callers get their own copy")
+		assert.Equal(t, report.StartedAt, again.StartedAt)
+		assert.Len(t, local.Requests(), 2, "a recent report is reused")
+	})
+
+	t.Run("should fail on unexpected probe statuses", func(t *testing.T) {
+		for _, status := range []int{http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusTooManyRequests} {
+			answering := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+			}))
+			t.Cleanup(answering.Close)
+			client := glide.New(glide.WithAPIKey(glidetest.DefaultAPIKey), glide.WithBaseURL(answering.URL))
+
+			err := client.Ping(ctx)
+			require.Error(t, err, "HTTP %d", status)
+			assert.Equal(t, status, err.(*glide.Error).Status)
+
+			report := client.Diagnose(ctx)
+			assert.False(t, report.Healthy(), "HTTP %d", status)
+			assert.Equal(t, glide.DiagnosticFailed, report.Check(glide.CheckEndpoint).Status)
+			assert.Equal(t, glide.DiagnosticSkipped, report.Check(glide.CheckAuth).Status)
+		}
+	})
+
+	t.Run("should probe the configured health check path", func(t *testing.T) {
+		var probed string
+		custom := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			probed = r.URL.Path
+			w.Write([]byte(`{}`))
+		}))
+		t.Cleanup(custom.Close)
+
+		client := glide.New(glide.WithAPIKey(glidetest.DefaultAPIKey), glide.WithBaseURL(custom.URL), glide.WithHealthCheckPath("/v2/status"))
+		require.NoError(t, client.Ping(ctx))
+		assert.Equal(t, "/v2/status", probed)
+	})
+
+	t.Run("should not reuse a report cut short by its context", func(t *testing.T) {
+		local := glidetest.NewServer()
+		t.Cleanup(local.Close)
+		client := local.Client()
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		report := client.Diagnose(cancelled)
+		assert.False(t, report.Healthy())
+
+		report = client.Diagnose(ctx)
+		assert.True(t, report.Healthy(), "the checks run again")
+	})
+
+	t.Run("should apply the client's rate limit to probes", func(t *testing.T) {
+		local := glidetest.NewServer()
+		t.Cleanup(local.Close)
+		client := local.Client(glide.WithRateLimit(1, time.Minute))
+		require.NoError(t, client.Ping(ctx))
+
+		limited, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		requireGlideError(t, client.Ping(limited), glide.ErrCodeRateLimitExceeded, 0)
+
+		report := client.Diagnose(limited)
+		assert.True(t, report.Healthy(), "a rate limited probe does not fail readiness")
+		assert.Equal(t, glide.DiagnosticSkipped, report.Check(glide.CheckEndpoint).Status)
+		assert.Equal(t, 1, local.RequestCount(glidetest.PathHealth))
+	})
+}
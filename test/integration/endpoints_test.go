@@ -0,0 +1,180 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionalEndpoints(t *testing.T) {
+	ctx := context.Background()
+	req := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+
+	newRegions := func(t *testing.T) (*glidetest.Server, *glidetest.Server) {
+		us, eu := glidetest.NewServer(), glidetest.NewServer()
+		t.Cleanup(us.Close)
+		t.Cleanup(eu.Close)
+		return us, eu
+	}
+	newClient := func(t *testing.T, opts ...glide.Option) *glide.Client {
+		client, err := glide.NewWithError(append([]glide.Option{
+			glide.WithAPIKey(glidetest.DefaultAPIKey),
+			glide.WithRetry(2, time.Hour), // Failover does not wait for the retry delay
+		}, opts...)...)
+		require.NoError(t, err)
+		return client
+	}
+	check := func(t *testing.T, client *glide.Client, opts ...glide.CallOption) (glide.ResponseMeta, error) {
+		var meta glide.ResponseMeta
+		_, err := client.SimSwap.Check(ctx, req, append(opts, glide.WithResponseMeta(&meta))...)
+		return meta, err
+	}
+
+	t.Run("should fail over to the fallback and skip the failed endpoint during its cooldown", func(t *testing.T) {
+		us, eu := newRegions(t)
+		client := newClient(t, glide.WithEndpoints(glide.EndpointPolicyFailover,
+			glide.Endpoint{URL: us.URL, Region: "us"},
+			glide.Endpoint{URL: eu.URL, Region: "eu"},
+		))
+
+		us.Script(glidetest.PathSimSwapCheck, glidetest.ServiceUnavailable())
+		meta, err := check(t, client)
+		require.NoError(t, err)
+		assert.Equal(t, 2, meta.Attempts)
+		assert.Equal(t, eu.URL, meta.Endpoint)
+		assert.Equal(t, "eu", meta.Region)
+
+		meta, err = check(t, client)
+		require.NoError(t, err)
+		assert.Equal(t, "eu", meta.Region)
+		assert.Equal(t, 1, us.RequestCount(glidetest.PathSimSwapCheck), "the primary is skipped during its cooldown")
+
+		status := client.Endpoints()
+		require.Len(t, status, 2)
+		assert.False(t, status[0].Healthy)
+		assert.Equal(t, 1, status[0].Failures)
+		assert.True(t, status[1].Healthy)
+		assert.Greater(t, status[1].Latency, time.Duration(0))
+	})
+
+	t.Run("should return to the primary once its cooldown ends", func(t *testing.T) {
+		us, eu := newRegions(t)
+		client := newClient(t,
+			glide.WithEndpoints(glide.EndpointPolicyFailover, glide.Endpoint{URL: us.URL, Region: "us"}, glide.Endpoint{URL: eu.URL, Region: "eu"}),
+			glide.WithEndpointCooldown(20*time.Millisecond),
+		)
+
+		us.Script(glidetest.PathSimSwapCheck, glidetest.ServiceUnavailable())
+		meta, err := check(t, client)
+		require.NoError(t, err)
+		assert.Equal(t, "eu", meta.Region)
+
+		time.Sleep(30 * time.Millisecond)
+		meta, err = check(t, client)
+		require.NoError(t, err)
+		assert.Equal(t, "us", meta.Region)
+	})
+
+	t.Run("should only fail over on endpoint failures", func(t *testing.T) {
+		us, eu := newRegions(t)
+		client := newClient(t,
+			glide.WithEndpoints(glide.EndpointPolicyFailover, glide.Endpoint{URL: us.URL, Region: "us"}, glide.Endpoint{URL: eu.URL, Region: "eu"}),
+			glide.WithRetry(1, time.Millisecond),
+		)
+
+		us.Script(glidetest.PathSimSwapCheck, glidetest.Error(http.StatusTooManyRequests, glide.ErrCodeRateLimitExceeded, "Slow down"))
+		meta, err := check(t, client)
+		require.NoError(t, err)
+		assert.Equal(t, 2, meta.Attempts)
+		assert.Equal(t, "us", meta.Region, "a rate limited call is retried on the same endpoint")
+		assert.Zero(t, eu.RequestCount(glidetest.PathSimSwapCheck))
+	})
+
+	t.Run("should prefer the endpoint with the lowest latency", func(t *testing.T) {
+		us, eu := newRegions(t)
+		client := newClient(t, glide.WithEndpoints(glide.EndpointPolicyLowestLatency,
+			glide.Endpoint{URL: us.URL, Region: "us"},
+			glide.Endpoint{URL: eu.URL, Region: "eu"},
+		))
+		us.Script(glidetest.PathSimSwapCheck, glidetest.Latency(50*time.Millisecond))
+
+		var regions []string
+		for i := 0; i < 4; i++ {
+			meta, err := check(t, client)
+			require.NoError(t, err)
+			regions = append(regions, meta.Region)
+		}
+		assert.Equal(t, []string{"us", "eu", "eu", "eu"}, regions, "unmeasured endpoints are tried first")
+	})
+
+	t.Run("should keep calls pinned to their data residency", func(t *testing.T) {
+		us, eu := newRegions(t)
+		endpoints := glide.WithEndpoints(glide.EndpointPolicyFailover,
+			glide.Endpoint{URL: us.URL, Region: "us", Countries: []string{"US", "CA"}},
+			glide.Endpoint{URL: eu.URL, Region: "eu", Countries: []string{"DE", "France"}},
+		)
+
+		pinned := newClient(t, endpoints, glide.WithDataResidency("Germany"), glide.WithRetry(1, time.Millisecond))
+		eu.Script(glidetest.PathSimSwapCheck, glidetest.ServiceUnavailable(), glidetest.ServiceUnavailable())
+		meta, err := check(t, pinned)
+		requireGlideError(t, err, glide.ErrCodeServiceUnavailable, http.StatusServiceUnavailable)
+		assert.Equal(t, "eu", meta.Region)
+		assert.Zero(t, us.RequestCount(glidetest.PathSimSwapCheck), "pinned calls never leave the region")
+
+		client := newClient(t, endpoints)
+		meta, err = check(t, client, glide.WithCallDataResidency("FR"))
+		require.NoError(t, err)
+		assert.Equal(t, "eu", meta.Region)
+
+		_, err = check(t, client, glide.WithCallDataResidency("JP"))
+		glideErr := requireGlideError(t, err, glide.ErrCodeValidationError, 0)
+		assert.Equal(t, "data_residency", glideErr.Violations()[0].Field)
+	})
+
+	t.Run("should use the base URL without endpoints", func(t *testing.T) {
+		server := glidetest.NewServer()
+		t.Cleanup(server.Close)
+		meta, err := check(t, newClient(t, glide.WithBaseURL(server.URL+"/")))
+		require.NoError(t, err)
+		assert.Equal(t, server.URL, meta.Endpoint)
+		assert.Empty(t, meta.Region)
+	})
+
+	t.Run("should load and validate endpoints from a config file", func(t *testing.T) {
+		t.Setenv("GLIDE_CONFIG", "")
+		cfg, err := glide.LoadConfig(writeConfigFile(t, "glide.yaml", `
+api_key: key
+endpoint_policy: lowest_latency
+data_residency: DE
+endpoints:
+  - url: https://us.api.glideidentity.app
+    region: us
+  - url: https://eu.api.glideidentity.app
+    region: eu
+    countries: [DE, FR]
+`))
+		require.NoError(t, err)
+		assert.Equal(t, glide.EndpointPolicyLowestLatency, cfg.EndpointPolicy)
+		assert.Equal(t, []glide.Endpoint{
+			{URL: "https://us.api.glideidentity.app", Region: "us"},
+			{URL: "https://eu.api.glideidentity.app", Region: "eu", Countries: []string{"DE", "FR"}},
+		}, cfg.Endpoints)
+		require.NoError(t, cfg.Validate())
+
+		_, err = glide.NewWithError(
+			glide.WithAPIKey("key"),
+			glide.WithEndpoints(glide.EndpointPolicyFailover, glide.Endpoint{URL: "eu.api.glideidentity.app", Countries: []string{"Atlantis"}}),
+			glide.WithDataResidency("JP"),
+		)
+		assert.ElementsMatch(t, []string{"endpoints[0].url", "endpoints[0].countries", "data_residency"}, violationFields(t, err))
+
+		_, err = glide.LoadConfig(writeConfigFile(t, "glide.yaml", "endpoint_policy: random\nendpoints: https://eu.api.glideidentity.app\n"))
+		assert.ElementsMatch(t, []string{"endpoint_policy", "endpoints"}, violationFields(t, err))
+	})
+}
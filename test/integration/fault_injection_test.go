@@ -0,0 +1,169 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFaultClient returns a client whose traffic to a glidetest server goes through a FaultTransport
+func newFaultClient(t *testing.T, opts ...glidetest.FaultOption) (*glide.Client, *glidetest.Server, *glidetest.FaultTransport) {
+	server := glidetest.NewServer()
+	t.Cleanup(server.Close)
+	faults := glidetest.NewFaultTransport(nil, opts...)
+	client := server.Client(
+		glide.WithHTTPClient(faults.Client()),
+		glide.WithRetry(2, time.Millisecond),
+	)
+	return client, server, faults
+}
+
+// requireGlideError asserts err is a *glide.Error with the given code and status
+func requireGlideError(t *testing.T, err error, code string, status int) *glide.Error {
+	t.Helper()
+	require.Error(t, err)
+	glideErr, ok := err.(*glide.Error)
+	require.True(t, ok, "expected *glide.Error, got %T", err)
+	assert.Equal(t, code, glideErr.Code)
+	assert.Equal(t, status, glideErr.Status)
+	return glideErr
+}
+
+func TestFaultInjection(t *testing.T) {
+	ctx := context.Background()
+	req := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+
+	t.Run("should retry connection resets", func(t *testing.T) {
+		client, _, faults := newFaultClient(t)
+		faults.Script(glidetest.FaultConnectionReset)
+
+		var meta glide.ResponseMeta
+		_, err := client.SimSwap.Check(ctx, req, glide.WithResponseMeta(&meta))
+		require.NoError(t, err)
+		assert.Equal(t, 2, meta.Attempts)
+
+		faults.Script(glidetest.Repeat(glidetest.FaultConnectionReset, 3)...)
+		_, err = client.SimSwap.Check(ctx, req, glide.WithResponseMeta(&meta))
+		glideErr := requireGlideError(t, err, glide.ErrCodeServiceUnavailable, 0)
+		assert.True(t, glideErr.IsRetryable())
+		assert.Equal(t, 3, meta.Attempts)
+	})
+
+	t.Run("should retry timeouts and stop at the call deadline", func(t *testing.T) {
+		client, _, faults := newFaultClient(t, glidetest.WithTimeoutAfter(10*time.Millisecond))
+		faults.Script(glidetest.FaultTimeout)
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+
+		hanging, _, hangingFaults := newFaultClient(t)
+		hangingFaults.Script(glidetest.Repeat(glidetest.FaultTimeout, 3)...)
+		var meta glide.ResponseMeta
+		start := time.Now()
+		_, err = hanging.SimSwap.Check(ctx, req, glide.WithCallTimeout(50*time.Millisecond), glide.WithResponseMeta(&meta))
+		requireGlideError(t, err, glide.ErrCodeServiceUnavailable, 0)
+		assert.Equal(t, 1, meta.Attempts, "no retries once the deadline has passed")
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should read slow bodies and fail them at the call deadline", func(t *testing.T) {
+		client, _, faults := newFaultClient(t, glidetest.WithSlowBodyDelay(2*time.Millisecond))
+		faults.Script(glidetest.FaultSlowBody)
+		resp, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		assert.False(t, resp.Swapped)
+
+		slow, _, slowFaults := newFaultClient(t, glidetest.WithSlowBodyDelay(20*time.Millisecond))
+		slowFaults.Script(glidetest.Repeat(glidetest.FaultSlowBody, 3)...)
+		var meta glide.ResponseMeta
+		_, err = slow.SimSwap.Check(ctx, req, glide.WithCallTimeout(50*time.Millisecond), glide.WithResponseMeta(&meta))
+		glideErr := requireGlideError(t, err, glide.ErrCodeInternalServerError, 0)
+		assert.Equal(t, "Failed to read response body", glideErr.Message)
+		assert.Equal(t, 1, meta.Attempts)
+	})
+
+	t.Run("should report truncated JSON without retrying", func(t *testing.T) {
+		client, server, faults := newFaultClient(t)
+		faults.Script(glidetest.FaultTruncatedJSON)
+		_, err := client.SimSwap.Check(ctx, req)
+		glideErr := requireGlideError(t, err, glide.ErrCodeInternalServerError, 0)
+		assert.Equal(t, "Failed to parse response", glideErr.Message)
+		assert.Equal(t, 1, server.RequestCount(glidetest.PathSimSwapCheck))
+
+		// A truncated error body falls back to the error for its status
+		server.Script(glidetest.PathSimSwapCheck, glidetest.CarrierNotEligible())
+		faults.Script(glidetest.FaultTruncatedJSON)
+		_, err = client.SimSwap.Check(ctx, req)
+		requireGlideError(t, err, glide.ErrCodeUnprocessableEntity, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("should ride out short 5xx bursts and surface long ones", func(t *testing.T) {
+		client, server, faults := newFaultClient(t)
+		faults.Script(glidetest.Repeat(glidetest.FaultServerError, 2)...)
+		var meta glide.ResponseMeta
+		_, err := client.SimSwap.Check(ctx, req, glide.WithResponseMeta(&meta))
+		require.NoError(t, err)
+		assert.Equal(t, 3, meta.Attempts)
+		assert.Equal(t, 1, server.RequestCount(glidetest.PathSimSwapCheck), "injected 5xx never reach the server")
+
+		faults.Script(glidetest.Repeat(glidetest.FaultServerError, 3)...)
+		_, err = client.SimSwap.Check(ctx, req, glide.WithResponseMeta(&meta))
+		requireGlideError(t, err, glide.ErrCodeServiceUnavailable, http.StatusServiceUnavailable)
+		assert.Equal(t, 3, meta.Attempts)
+	})
+
+	t.Run("should fall back to status-based errors for malformed error bodies", func(t *testing.T) {
+		client, _, faults := newFaultClient(t)
+		faults.Script(glidetest.FaultMalformedErrorBody)
+		var meta glide.ResponseMeta
+		_, err := client.SimSwap.Check(ctx, req, glide.WithResponseMeta(&meta))
+		require.NoError(t, err, "502 is retried")
+		assert.Equal(t, 2, meta.Attempts)
+
+		faults.Script(glidetest.Repeat(glidetest.FaultMalformedErrorBody, 3)...)
+		_, err = client.SimSwap.Check(ctx, req)
+		glideErr := requireGlideError(t, err, glide.ErrCodeInternalServerError, http.StatusBadGateway)
+		assert.Equal(t, "Server error occurred", glideErr.Message)
+
+		badRequest, _, badFaults := newFaultClient(t, glidetest.WithMalformedErrorStatus(http.StatusBadRequest))
+		badFaults.Script(glidetest.FaultMalformedErrorBody)
+		_, err = badRequest.SimSwap.Check(ctx, req, glide.WithResponseMeta(&meta))
+		requireGlideError(t, err, glide.ErrCodeBadRequest, http.StatusBadRequest)
+		assert.Equal(t, 1, meta.Attempts, "4xx is not retried")
+	})
+
+	t.Run("should inject faults probabilistically and only on selected paths", func(t *testing.T) {
+		client, _, faults := newFaultClient(t,
+			glidetest.WithFaultProbability(glidetest.FaultServerError, 0.5),
+			glidetest.WithFaultSeed(42),
+			glidetest.WithFaultPaths(glidetest.PathSimSwapCheck),
+		)
+
+		failures := 0
+		for i := 0; i < 40; i++ {
+			if _, err := client.SimSwap.Check(ctx, req, glide.WithCallRetry(0)); err != nil {
+				failures++
+			}
+		}
+		injected := 0
+		for _, fault := range faults.Injected() {
+			if fault == glidetest.FaultServerError {
+				injected++
+			}
+		}
+		assert.Equal(t, injected, failures)
+		assert.Greater(t, failures, 5)
+		assert.Less(t, failures, 35)
+
+		for i := 0; i < 10; i++ {
+			_, err := client.SimSwap.GetLastSwapDate(ctx, &glide.SimSwapDateRequest{PhoneNumber: testPhoneNumbers.TMobileValid},
+				glide.WithCallRetry(0))
+			require.NoError(t, err)
+		}
+	})
+}
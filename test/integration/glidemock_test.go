@@ -0,0 +1,102 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidemock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeT records failures reported by AssertExpectations
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestGlideMock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should answer from canned responses without HTTP", func(t *testing.T) {
+		mocks := glidemock.New()
+		mocks.SimSwap.OnCheck().Return(&glide.SimSwapCheckResponse{Swapped: true}, nil)
+		mocks.KYC.OnMatch().Run(func(ctx context.Context, req *glide.KYCMatchRequest) (*glide.KYCMatchResponse, error) {
+			return &glide.KYCMatchResponse{OverallMatch: req.Name == "Jane"}, nil
+		})
+
+		client := mocks.Client(glide.WithBaseURL("http://127.0.0.1:0"))
+		swap, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.NoError(t, err)
+		assert.True(t, swap.Swapped)
+
+		match, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, Name: "Jane"})
+		require.NoError(t, err)
+		assert.True(t, match.OverallMatch)
+
+		assert.True(t, mocks.AssertExpectations(t))
+	})
+
+	t.Run("should match requests and honor call counts in order", func(t *testing.T) {
+		mocks := glidemock.New()
+		notEligible := glide.NewError(glide.ErrCodeCarrierNotEligible, "Carrier not eligible")
+		mocks.MagicAuth.OnPrepare().
+			Match(func(req *glide.PrepareRequest) bool { return req.PhoneNumber == testPhoneNumbers.NonEligible }).
+			ReturnError(notEligible)
+		mocks.MagicAuth.OnPrepare().Once().Return(&glide.PrepareResponse{AuthenticationStrategy: glide.AuthenticationStrategyTS43}, nil)
+
+		client := mocks.Client()
+		_, err := client.MagicAuth.Prepare(ctx, &glide.PrepareRequest{PhoneNumber: testPhoneNumbers.NonEligible})
+		assert.True(t, errors.Is(err, notEligible))
+
+		resp, err := client.MagicAuth.Prepare(ctx, &glide.PrepareRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.NoError(t, err)
+		assert.Equal(t, glide.AuthenticationStrategyTS43, resp.AuthenticationStrategy)
+
+		_, err = client.MagicAuth.Prepare(ctx, &glide.PrepareRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		assert.True(t, errors.Is(err, glidemock.ErrUnexpectedCall), "the Once expectation is used up")
+
+		calls := mocks.MagicAuth.Calls("MagicAuth.Prepare")
+		require.Len(t, calls, 3)
+		assert.Equal(t, testPhoneNumbers.NonEligible, calls[0].Request.(*glide.PrepareRequest).PhoneNumber)
+
+		ft := &fakeT{}
+		assert.False(t, mocks.AssertExpectations(ft))
+		require.Len(t, ft.errors, 1)
+		assert.Contains(t, ft.errors[0], "unexpected call to MagicAuth.Prepare")
+	})
+
+	t.Run("should report expectations that were not met", func(t *testing.T) {
+		mocks := glidemock.New()
+		mocks.NumberVerify.OnVerify().Times(2).Return(&glide.NumberVerifyResponse{Verified: true}, nil)
+		mocks.LocationVerification.OnVerify().Return(&glide.LocationVerifyResponse{}, nil)
+
+		_, err := mocks.Client().NumberVerify.Verify(ctx, &glide.NumberVerifyRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.NoError(t, err)
+
+		ft := &fakeT{}
+		assert.False(t, mocks.AssertExpectations(ft))
+		assert.Equal(t, []string{
+			"glidemock: NumberVerify.Verify expectation #1: expected 2 call(s), got 1",
+			"glidemock: LocationVerification.Verify expectation #1: expected at least one call, got none",
+		}, ft.errors)
+	})
+
+	t.Run("should keep default services that are not replaced", func(t *testing.T) {
+		simSwap := glidemock.NewSimSwapService()
+		client := glide.NewClientWithServices(glide.Services{SimSwap: simSwap}, glide.WithAPIKey("test-key"))
+
+		assert.Same(t, simSwap, client.SimSwap)
+		assert.NotNil(t, client.KYC)
+		_, isMock := client.KYC.(*glidemock.KYCService)
+		assert.False(t, isMock)
+	})
+}
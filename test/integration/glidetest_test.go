@@ -0,0 +1,140 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlideTestServer(t *testing.T) {
+	ctx := context.Background()
+	server := glidetest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	t.Run("should complete the magic auth flow offline", func(t *testing.T) {
+		prepared, err := client.MagicAuth.Prepare(ctx, &glide.PrepareRequest{
+			UseCase:     glide.UseCaseVerifyPhoneNumber,
+			PhoneNumber: testPhoneNumbers.TMobileValid,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, glide.AuthenticationStrategyTS43, prepared.AuthenticationStrategy)
+		require.NotEmpty(t, prepared.Session.SessionKey)
+
+		verifyReq := &glide.VerifyPhoneNumberRequest{Session: prepared.Session, Credential: "eyJhbGciOiJFUzI1NiJ9.mock"}
+		verified, err := client.MagicAuth.VerifyPhoneNumber(ctx, verifyReq)
+		require.NoError(t, err)
+		assert.True(t, verified.Verified)
+		assert.Equal(t, testPhoneNumbers.TMobileValid, verified.PhoneNumber)
+
+		// Sessions are single use
+		_, err = client.MagicAuth.VerifyPhoneNumber(ctx, verifyReq)
+		require.Error(t, err)
+		assert.Equal(t, glide.ErrCodeSessionNotFound, err.(*glide.Error).Code)
+	})
+
+	t.Run("should reject ineligible carriers and unsupported browsers", func(t *testing.T) {
+		_, err := client.MagicAuth.Prepare(ctx, &glide.PrepareRequest{
+			UseCase:     glide.UseCaseVerifyPhoneNumber,
+			PhoneNumber: testPhoneNumbers.NonEligible,
+		})
+		require.Error(t, err)
+		assert.Equal(t, glide.ErrCodeCarrierNotEligible, err.(*glide.Error).Code)
+
+		_, err = client.MagicAuth.Prepare(ctx, &glide.PrepareRequest{
+			UseCase:    glide.UseCaseGetPhoneNumber,
+			PLMN:       &glide.PLMN{MCC: "310", MNC: "260"},
+			ClientInfo: &glide.ClientInfo{UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:109.0) Gecko/20100101 Firefox/118.0"},
+		})
+		require.Error(t, err)
+		assert.Equal(t, glide.ErrCodeUnsupportedPlatform, err.(*glide.Error).Code)
+	})
+
+	t.Run("should serve the other products with default responses", func(t *testing.T) {
+		swap, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.NoError(t, err)
+		assert.False(t, swap.Swapped)
+
+		date, err := client.SimSwap.GetLastSwapDate(ctx, &glide.SimSwapDateRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.NoError(t, err)
+		assert.NotNil(t, date.LastSwapDate)
+
+		number, err := client.NumberVerify.Verify(ctx, &glide.NumberVerifyRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.NoError(t, err)
+		assert.True(t, number.Verified)
+
+		match, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, GivenName: "Jane"})
+		require.NoError(t, err)
+		assert.True(t, match.OverallMatch)
+		assert.True(t, match.MatchResults["given_name"].Matched)
+	})
+
+	t.Run("should script session expiry, rate limiting and latency", func(t *testing.T) {
+		server.Reset()
+
+		prepared, err := client.MagicAuth.Prepare(ctx, &glide.PrepareRequest{
+			UseCase: glide.UseCaseGetPhoneNumber,
+			PLMN:    &glide.PLMN{MCC: testPLMN.TMobileUS.MCC, MNC: testPLMN.TMobileUS.MNC},
+		})
+		require.NoError(t, err)
+		server.ExpireSessions()
+		_, err = client.MagicAuth.GetPhoneNumber(ctx, &glide.GetPhoneNumberRequest{Session: prepared.Session, Credential: "token"})
+		require.Error(t, err)
+		assert.Equal(t, glide.ErrCodeSessionNotFound, err.(*glide.Error).Code)
+
+		server.Script(glidetest.PathSimSwapCheck, glidetest.RateLimited(2*time.Second))
+		_, err = client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		assertRateLimited(t, err)
+		assert.Equal(t, float64(2), err.(*glide.Error).Details["retry_after"])
+
+		// A fresh client, as the first one now waits out the Retry-After
+		server.Script(glidetest.AnyPath, glidetest.Latency(50*time.Millisecond))
+		_, err = server.Client().SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid},
+			glide.WithCallTimeout(10*time.Millisecond))
+		require.Error(t, err, "latency exceeds the call timeout")
+
+		server.Always(glidetest.PathKYCMatch, glidetest.CarrierNotEligible())
+		_, err = server.Client().KYC.Match(ctx, &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, Name: "Jane"})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.(*glide.Error).Status)
+		assert.Equal(t, 1, server.RequestCount(glidetest.PathKYCMatch))
+	})
+
+	t.Run("should replay responses for a repeated idempotency key", func(t *testing.T) {
+		server.Reset()
+		client := server.Client()
+		req := &glide.NumberVerifyRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+
+		var first, second glide.ResponseMeta
+		_, err := client.NumberVerify.Verify(ctx, req, glide.WithIdempotencyKey("same-key"), glide.WithResponseMeta(&first))
+		require.NoError(t, err)
+		_, err = client.NumberVerify.Verify(ctx, req, glide.WithIdempotencyKey("same-key"), glide.WithResponseMeta(&second))
+		require.NoError(t, err)
+
+		assert.Empty(t, first.Header.Get("Idempotent-Replayed"))
+		assert.Equal(t, "true", second.Header.Get("Idempotent-Replayed"))
+		assert.Equal(t, "same-key", server.Requests()[1].IdempotencyKey)
+		assert.NotEmpty(t, second.RequestID)
+		assert.NotEqual(t, first.RequestID, second.RequestID, "a replay keeps a fresh request ID")
+	})
+
+	t.Run("should reject unknown API keys", func(t *testing.T) {
+		_, err := server.Client(glide.WithAPIKey("wrong-key")).SimSwap.Check(ctx,
+			&glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		requireGlideError(t, err, glide.ErrCodeInternalServerError, http.StatusUnauthorized)
+		assert.Equal(t, "Invalid API key", err.(*glide.Error).Message)
+		assert.NotEmpty(t, err.(*glide.Error).RequestID)
+	})
+
+	t.Run("should include a code in scripted errors without one", func(t *testing.T) {
+		server.Script(glidetest.PathSimSwapCheck, glidetest.Scenario{Status: http.StatusUnprocessableEntity})
+		_, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		requireGlideError(t, err, glide.ErrCodeUnprocessableEntity, http.StatusUnprocessableEntity)
+	})
+}
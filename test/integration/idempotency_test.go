@@ -0,0 +1,133 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// idempotencyServer fails the first failures calls with 503 and records the Idempotency-Key of each call
+type idempotencyServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	keys     []string
+	failures int
+}
+
+func newIdempotencyServer(t *testing.T, failures int, response string) *idempotencyServer {
+	s := &idempotencyServer{failures: failures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.keys = append(s.keys, r.Header.Get("Idempotency-Key"))
+		attempt := len(s.keys)
+		s.mu.Unlock()
+
+		if attempt <= s.failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *idempotencyServer) receivedKeys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.keys...)
+}
+
+func (s *idempotencyServer) reset() {
+	s.mu.Lock()
+	s.keys = nil
+	s.mu.Unlock()
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	prepareResponse := `{"authentication_strategy":"ts43","session":{"session_key":"abc"},"data":{}}`
+	prepareReq := &glide.PrepareRequest{
+		UseCase:     glide.UseCaseVerifyPhoneNumber,
+		PhoneNumber: testPhoneNumbers.TMobileValid,
+	}
+
+	t.Run("should keep the generated key stable across retries", func(t *testing.T) {
+		server := newIdempotencyServer(t, 2, prepareResponse)
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(3, time.Millisecond),
+		)
+
+		_, err := client.MagicAuth.Prepare(ctx, prepareReq)
+		require.NoError(t, err)
+
+		keys := server.receivedKeys()
+		require.Len(t, keys, 3)
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, keys[0])
+		assert.Equal(t, keys[0], keys[1])
+		assert.Equal(t, keys[0], keys[2])
+
+		// A new logical call gets a new key
+		_, err = client.MagicAuth.Prepare(ctx, prepareReq)
+		require.NoError(t, err)
+		assert.NotEqual(t, keys[0], server.receivedKeys()[3])
+	})
+
+	t.Run("should use the caller key and surface it in errors", func(t *testing.T) {
+		server := newIdempotencyServer(t, 10, prepareResponse)
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(1, time.Millisecond),
+		)
+
+		_, err := client.MagicAuth.Prepare(ctx, prepareReq, glide.WithIdempotencyKey("order-42"))
+		require.Error(t, err)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, "order-42", glideErr.IdempotencyKey)
+		assert.Equal(t, []string{"order-42", "order-42"}, server.receivedKeys())
+	})
+
+	t.Run("should only retry safe operations when keys are disabled", func(t *testing.T) {
+		server := newIdempotencyServer(t, 10, "{}")
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(2, time.Millisecond),
+			glide.WithAutoIdempotencyKeys(false),
+		)
+
+		_, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, Name: "Jane"})
+		require.Error(t, err)
+		assert.Equal(t, []string{""}, server.receivedKeys(), "non-idempotent call is not retried")
+
+		server.reset()
+		_, err = client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.Error(t, err)
+		assert.Len(t, server.receivedKeys(), 3, "read-only call is retried")
+
+		// A caller-supplied key re-enables retries
+		server.reset()
+		_, err = client.KYC.Match(ctx, &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, Name: "Jane"},
+			glide.WithIdempotencyKey("kyc-1"))
+		require.Error(t, err)
+		assert.Equal(t, []string{"kyc-1", "kyc-1", "kyc-1"}, server.receivedKeys())
+
+		// So does one set as a plain header
+		server.reset()
+		_, err = client.KYC.Match(ctx, &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, Name: "Jane"},
+			glide.WithHeader("idempotency-key", "kyc-2"))
+		require.Error(t, err)
+		assert.Equal(t, []string{"kyc-2", "kyc-2", "kyc-2"}, server.receivedKeys())
+	})
+}
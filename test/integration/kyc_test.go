@@ -0,0 +1,377 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newKYCTestServer starts a server that records the request body and replies with the given JSON
+func newKYCTestServer(t *testing.T, path, response string, received *map[string]interface{}, opts ...glide.Option) *glide.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, path, r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(received))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	return glide.New(append([]glide.Option{
+		glide.WithAPIKey("test-key"),
+		glide.WithBaseURL(server.URL),
+		glide.WithRetry(0, 0),
+	}, opts...)...)
+}
+
+func TestKYCAgeVerify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should send threshold and optional identity fields", func(t *testing.T) {
+		var received map[string]interface{}
+		client := newKYCTestServer(t, "/kyc-age-verification/verify",
+			`{"age_check":"true","verified_status":true,"identity_match_score":90,"checked_at":"2025-01-01T10:00:00Z"}`,
+			&received)
+
+		resp, err := client.KYC.AgeVerify(ctx, &glide.KYCAgeVerifyRequest{
+			PhoneNumber:  testPhoneNumbers.TMobileValid,
+			AgeThreshold: 18,
+			GivenName:    "Jane",
+			BirthDate:    "1990-01-15",
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.IsAboveThreshold())
+		require.NotNil(t, resp.IdentityMatchScore)
+		assert.Equal(t, 90, *resp.IdentityMatchScore)
+
+		assert.Equal(t, float64(18), received["age_threshold"])
+		assert.Equal(t, "Jane", received["given_name"])
+		assert.NotContains(t, received, "family_name")
+	})
+
+	t.Run("should return per-field identity match results", func(t *testing.T) {
+		var received map[string]interface{}
+		client := newKYCTestServer(t, "/kyc-age-verification/verify",
+			`{"age_check":"true","identity_match_score":60,"match_results":{"given_name":{"result":"true"},"family_name":{"result":"false","match_score":85},"birth_date":{"result":"not_available"}},"checked_at":"2025-01-01T10:00:00Z"}`,
+			&received)
+
+		resp, err := client.KYC.AgeVerify(ctx, &glide.KYCAgeVerifyRequest{
+			PhoneNumber:  testPhoneNumbers.TMobileValid,
+			AgeThreshold: 18,
+			GivenName:    "Jane",
+			FamilyName:   "Doe",
+			BirthDate:    "1990-01-15",
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.MatchResults, 3)
+		assert.True(t, resp.MatchResults["given_name"].Matched)
+		assert.Equal(t, glide.KYCCheckFalse, resp.MatchResults["family_name"].Status())
+		assert.Equal(t, glide.KYCCheckNotAvailable, resp.MatchResults["birth_date"].Status())
+
+		eval := resp.Evaluate(glide.MatchThresholds{RequiredFields: []string{"birth_date"}})
+		assert.False(t, eval.Passed)
+		assert.Equal(t, []string{"given_name"}, eval.Matched)
+		assert.Equal(t, []string{"family_name"}, eval.Partial)
+		assert.Equal(t, []string{"birth_date"}, eval.Failed)
+	})
+
+	t.Run("should reject invalid requests locally", func(t *testing.T) {
+		client := glide.New(glide.WithAPIKey("test-key"), glide.WithBaseURL("http://127.0.0.1:0"))
+
+		testCases := []struct {
+			name string
+			req  *glide.KYCAgeVerifyRequest
+			code string
+		}{
+			{"missing phone", &glide.KYCAgeVerifyRequest{AgeThreshold: 18}, glide.ErrCodeMissingParameters},
+			{"missing threshold", &glide.KYCAgeVerifyRequest{PhoneNumber: testPhoneNumbers.TMobileValid}, glide.ErrCodeMissingParameters},
+			{"threshold out of range", &glide.KYCAgeVerifyRequest{PhoneNumber: testPhoneNumbers.TMobileValid, AgeThreshold: 150}, glide.ErrCodeValidationError},
+			{"bad birth date", &glide.KYCAgeVerifyRequest{PhoneNumber: testPhoneNumbers.TMobileValid, AgeThreshold: 18, BirthDate: "15/01/1990"}, glide.ErrCodeValidationError},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := client.KYC.AgeVerify(ctx, tc.req)
+				require.Error(t, err)
+				glideErr, ok := err.(*glide.Error)
+				require.True(t, ok)
+				assert.Equal(t, tc.code, glideErr.Code)
+			})
+		}
+	})
+}
+
+func TestKYCFillIn(t *testing.T) {
+	ctx := context.Background()
+	consent := &glide.ConsentData{
+		ConsentText: "I agree to share my identity",
+		PolicyLink:  "https://example.com/privacy",
+		PolicyText:  "Privacy policy",
+	}
+
+	t.Run("should return identity with per-field availability", func(t *testing.T) {
+		var received map[string]interface{}
+		client := newKYCTestServer(t, "/kyc-fill-in/retrieve",
+			`{"identity":{"given_name":"Jane","family_name":"Doe","birth_date":"1990-01-15","email":null,"locality":""},"checked_at":"2025-01-01T10:00:00Z"}`,
+			&received)
+
+		resp, err := client.KYC.FillIn(ctx, &glide.KYCFillInRequest{
+			PhoneNumber: testPhoneNumbers.TMobileValid,
+			ConsentData: consent,
+		})
+		require.NoError(t, err)
+
+		assert.True(t, resp.Identity.GivenName.Available)
+		assert.Equal(t, "Jane", resp.Identity.GivenName.Value)
+		assert.False(t, resp.Identity.Email.Available)
+		assert.False(t, resp.Identity.Locality.Available)
+		assert.False(t, resp.Identity.IDDocument.Available)
+		assert.Equal(t, []string{"given_name", "family_name", "birth_date"}, resp.Identity.AvailableFields())
+		assert.NotNil(t, received["consent_data"])
+	})
+
+	t.Run("should keep non-string values instead of failing the response", func(t *testing.T) {
+		var received map[string]interface{}
+		client := newKYCTestServer(t, "/kyc-fill-in/retrieve",
+			`{"identity":{"given_name":"Jane","street_number":12,"address":{"street": "Main Street", "locality":"Springfield"}},"checked_at":"2025-01-01T10:00:00Z"}`,
+			&received)
+
+		resp, err := client.KYC.FillIn(ctx, &glide.KYCFillInRequest{
+			PhoneNumber: testPhoneNumbers.TMobileValid,
+			ConsentData: consent,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Jane", resp.Identity.GivenName.Value)
+		assert.Nil(t, resp.Identity.GivenName.Raw)
+		assert.True(t, resp.Identity.StreetNumber.Available)
+		assert.Equal(t, "12", resp.Identity.StreetNumber.Value)
+		assert.True(t, resp.Identity.Address.Available)
+		assert.JSONEq(t, `{"street":"Main Street","locality":"Springfield"}`, string(resp.Identity.Address.Raw))
+
+		encoded, err := json.Marshal(resp.Identity.Address)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"street":"Main Street","locality":"Springfield"}`, string(encoded))
+	})
+
+	t.Run("should redact identity when formatted", func(t *testing.T) {
+		identity := glide.KYCIdentity{
+			GivenName: glide.KYCField{Value: "Jane", Available: true},
+			BirthDate: glide.KYCField{Value: "1990-01-15", Available: true},
+		}
+
+		for _, formatted := range []string{fmt.Sprintf("%v", identity), fmt.Sprintf("%+v", identity), fmt.Sprintf("%#v", identity)} {
+			assert.NotContains(t, formatted, "Jane")
+			assert.NotContains(t, formatted, "1990-01-15")
+			assert.Contains(t, formatted, "given_name=J****[REDACTED]")
+		}
+	})
+
+	t.Run("should require consent", func(t *testing.T) {
+		client := glide.New(glide.WithAPIKey("test-key"), glide.WithBaseURL("http://127.0.0.1:0"))
+
+		_, err := client.KYC.FillIn(ctx, &glide.KYCFillInRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.Error(t, err)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, glide.ErrCodeMissingParameters, glideErr.Code)
+	})
+}
+
+func TestKYCMatchScores(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should send extended fields and parse scored results", func(t *testing.T) {
+		var received map[string]interface{}
+		client := newKYCTestServer(t, "/kyc-match/match",
+			`{"match_results":{"given_name":{"result":"true"},"family_name":{"result":"false","match_score":86},"birth_date":{"result":"false","match_score":40},"email":{"result":"not_available"}},"overall_match":false,"checked_at":"2025-01-01T10:00:00Z"}`,
+			&received)
+
+		resp, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{
+			PhoneNumber:    testPhoneNumbers.TMobileValid,
+			GivenName:      "Jane",
+			FamilyName:     "Doe",
+			MiddleNames:    "Marie",
+			Gender:         glide.GenderFemale,
+			Nationality:    "US",
+			IDDocumentType: "passport",
+			Address:        &glide.Address{Street: "Main Street", HouseNumber: "123", Region: "CA"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Marie", received["middle_names"])
+		assert.Equal(t, "FEMALE", received["gender"])
+		assert.Equal(t, "passport", received["id_document_type"])
+		address, ok := received["address"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "123", address["house_number"])
+
+		assert.True(t, resp.MatchResults["given_name"].Matched)
+		assert.Equal(t, glide.KYCCheckNotAvailable, resp.MatchResults["email"].Status())
+
+		eval := resp.Evaluate(glide.MatchThresholds{})
+		assert.False(t, eval.Passed)
+		assert.Equal(t, []string{"given_name"}, eval.Matched)
+		assert.Equal(t, []string{"family_name"}, eval.Partial)
+		assert.Equal(t, []string{"birth_date"}, eval.Failed)
+		assert.Equal(t, []string{"email"}, eval.NotAvailable)
+
+		eval = resp.Evaluate(glide.MatchThresholds{
+			Default: 90,
+			Fields:  map[string]int{"birth_date": 30},
+		})
+		assert.Equal(t, []string{"family_name"}, eval.Failed)
+
+		eval = resp.Evaluate(glide.MatchThresholds{
+			Fields:         map[string]int{"birth_date": 30},
+			RequiredFields: []string{"email"},
+		})
+		assert.Equal(t, []string{"email"}, eval.Failed)
+	})
+
+	t.Run("should reject invalid gender", func(t *testing.T) {
+		client := glide.New(glide.WithAPIKey("test-key"), glide.WithBaseURL("http://127.0.0.1:0"))
+
+		_, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{
+			PhoneNumber: testPhoneNumbers.TMobileValid,
+			Gender:      "UNKNOWN",
+		})
+		require.Error(t, err)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, glide.ErrCodeValidationError, glideErr.Code)
+	})
+}
+
+func TestKYCMatchNormalization(t *testing.T) {
+	ctx := context.Background()
+	response := `{"match_results":{},"overall_match":true,"checked_at":"2025-01-01T10:00:00Z"}`
+
+	t.Run("should send input unchanged by default", func(t *testing.T) {
+		var received map[string]interface{}
+		client := newKYCTestServer(t, "/kyc-match/match", response, &received)
+
+		_, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{
+			PhoneNumber: testPhoneNumbers.TMobileValid,
+			GivenName:   "  JOSÉ   María ",
+			Address:     &glide.Address{Street: "St. Mary  Ave."},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "  JOSÉ   María ", received["given_name"])
+		address, ok := received["address"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "St. Mary  Ave.", address["street"])
+	})
+
+	t.Run("should normalize input before sending", func(t *testing.T) {
+		var received map[string]interface{}
+		client := newKYCTestServer(t, "/kyc-match/match", response, &received, glide.WithKYCNormalization(true))
+
+		_, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{
+			PhoneNumber: testPhoneNumbers.TMobileValid,
+			GivenName:   "  JOSÉ   María ",
+			FamilyName:  "Ｇarcía",
+			Email:       " Jose.Garcia@Example.COM ",
+			Gender:      "female",
+			Nationality: "esp",
+			Address: &glide.Address{
+				Street:     "St. Mary  Ave.",
+				PostalCode: "sw1a 1aa",
+				Country:    "United Kingdom",
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "josé maría", received["given_name"])
+		assert.Equal(t, "garcía", received["family_name"])
+		assert.Equal(t, "jose.garcia@example.com", received["email"])
+		assert.Equal(t, "FEMALE", received["gender"])
+		assert.Equal(t, "ES", received["nationality"])
+		address, ok := received["address"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "st mary avenue", address["street"])
+		assert.Equal(t, "SW1A1AA", address["postal_code"])
+		assert.Equal(t, "GB", address["country"])
+	})
+
+	t.Run("should only expand street abbreviations in suffix position", func(t *testing.T) {
+		streets := map[string]string{
+			"Dr Martin Luther King Blvd": "dr martin luther king boulevard",
+			"St Johns Dr.":               "st johns drive",
+			"Main St Apt 4":              "main street apartment 4",
+			"5th Ave NW":                 "5th avenue nw",
+			"Ct Dr Way":                  "ct dr way",
+			"Main St , Apt 4":            "main street apartment 4",
+		}
+		for street, want := range streets {
+			var received map[string]interface{}
+			client := newKYCTestServer(t, "/kyc-match/match", response, &received, glide.WithKYCNormalization(true))
+
+			_, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{
+				PhoneNumber: testPhoneNumbers.TMobileValid,
+				Address:     &glide.Address{Street: street},
+			})
+			require.NoError(t, err)
+			address, ok := received["address"].(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, want, address["street"], street)
+		}
+	})
+
+	t.Run("should send hashed values when enabled", func(t *testing.T) {
+		var received map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.Write([]byte(response))
+		}))
+		defer server.Close()
+
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithKYCHashing(true),
+		)
+
+		_, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{
+			PhoneNumber: testPhoneNumbers.TMobileValid,
+			GivenName:   "Jane",
+			Nationality: "US",
+		})
+		require.NoError(t, err)
+
+		// sha256("jane")
+		assert.Equal(t, "81f8f6dde88365f3928796ec7aa53f72820b06db8664f5fe76a7eb13e24546a2", received["given_name"])
+		assert.Equal(t, "US", received["nationality"])
+		assert.Equal(t, testPhoneNumbers.TMobileValid, received["phone_number"])
+	})
+
+	t.Run("should reject invalid input", func(t *testing.T) {
+		client := glide.New(glide.WithAPIKey("test-key"), glide.WithBaseURL("http://127.0.0.1:0"), glide.WithKYCNormalization(true))
+
+		testCases := []struct {
+			name string
+			req  *glide.KYCMatchRequest
+		}{
+			{"impossible birth date", &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, BirthDate: "1990-02-30"}},
+			{"invalid month", &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, BirthDate: "1990-13-01"}},
+			{"unknown nationality", &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, Nationality: "Atlantis"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := client.KYC.Match(ctx, tc.req)
+				require.Error(t, err)
+				glideErr, ok := err.(*glide.Error)
+				require.True(t, ok)
+				assert.Equal(t, glide.ErrCodeValidationError, glideErr.Code)
+			})
+		}
+	})
+}
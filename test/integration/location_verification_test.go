@@ -0,0 +1,88 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationVerification(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/location-verification/verify", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"verification_result":"PARTIAL","match_rate":74,"last_location_time":"2025-01-01T10:00:00Z","checked_at":"2025-01-01T10:05:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := glide.New(
+		glide.WithAPIKey("test-key"),
+		glide.WithBaseURL(server.URL),
+		glide.WithRetry(0, 0),
+	)
+	ctx := context.Background()
+
+	t.Run("should verify circle area", func(t *testing.T) {
+		area := &glide.LocationArea{
+			AreaType: glide.AreaTypeCircle,
+			Center:   &glide.Point{Latitude: 50.735851, Longitude: 7.10066},
+			Radius:   50000,
+		}
+
+		resp, err := client.LocationVerification.Verify(ctx, testPhoneNumbers.TMobileValid, area, 120)
+		require.NoError(t, err)
+		assert.Equal(t, glide.LocationVerificationPartial, resp.VerificationResult)
+		assert.Equal(t, 74, resp.MatchRate)
+		require.NotNil(t, resp.LastLocationTime)
+
+		assert.Equal(t, testPhoneNumbers.TMobileValid, received["phone_number"])
+		assert.Equal(t, float64(120), received["max_age"])
+		sentArea, ok := received["area"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "CIRCLE", sentArea["area_type"])
+	})
+
+	t.Run("should reject invalid areas locally", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			area *glide.LocationArea
+		}{
+			{"missing area", nil},
+			{"unknown area type", &glide.LocationArea{AreaType: "SQUARE"}},
+			{"missing center", &glide.LocationArea{AreaType: glide.AreaTypeCircle, Radius: 5000}},
+			{"latitude out of range", &glide.LocationArea{AreaType: glide.AreaTypeCircle, Center: &glide.Point{Latitude: 91}, Radius: 5000}},
+			{"longitude out of range", &glide.LocationArea{AreaType: glide.AreaTypeCircle, Center: &glide.Point{Longitude: -181}, Radius: 5000}},
+			{"latitude is NaN", &glide.LocationArea{AreaType: glide.AreaTypeCircle, Center: &glide.Point{Latitude: math.NaN()}, Radius: 5000}},
+			{"longitude is infinite", &glide.LocationArea{AreaType: glide.AreaTypePolygon, Boundary: []glide.Point{{}, {Longitude: math.Inf(1)}, {Latitude: 1}}}},
+			{"radius too small", &glide.LocationArea{AreaType: glide.AreaTypeCircle, Center: &glide.Point{}, Radius: 100}},
+			{"polygon too few points", &glide.LocationArea{AreaType: glide.AreaTypePolygon, Boundary: []glide.Point{{}, {}}}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := client.LocationVerification.Verify(ctx, testPhoneNumbers.TMobileValid, tc.area, 0)
+				require.Error(t, err)
+				glideErr, ok := err.(*glide.Error)
+				require.True(t, ok)
+				assert.Contains(t, []string{glide.ErrCodeValidationError, glide.ErrCodeMissingParameters}, glideErr.Code)
+			})
+		}
+	})
+
+	t.Run("should report NaN coordinates as out of range", func(t *testing.T) {
+		area := &glide.LocationArea{AreaType: glide.AreaTypeCircle, Center: &glide.Point{Latitude: math.NaN(), Longitude: 7}, Radius: 5000}
+		_, err := client.LocationVerification.Verify(ctx, testPhoneNumbers.TMobileValid, area, 0)
+		glideErr := requireGlideError(t, err, glide.ErrCodeValidationError, 0)
+		require.Len(t, glideErr.Violations(), 1)
+		assert.Equal(t, "area.center.latitude", glideErr.Violations()[0].Field)
+		assert.Equal(t, glide.RuleRange, glideErr.Violations()[0].Rule)
+	})
+}
@@ -0,0 +1,147 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/phonenumber"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhoneNumberParsing(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		region      string
+		e164        string
+		countryCode int
+		numRegion   string
+		numType     phonenumber.NumberType
+	}{
+		{"US national formatted", "(415) 555-2671", "US", "+14155552671", 1, "US", phonenumber.NumberTypeFixedLineOrMobile},
+		{"US with trunk prefix", "1-415-555-2671", "US", "+14155552671", 1, "US", phonenumber.NumberTypeFixedLineOrMobile},
+		{"US international dialling", "011 44 7911 123456", "US", "+447911123456", 44, "GB", phonenumber.NumberTypeMobile},
+		{"Canadian area code", "+1 416 555 0123", "", "+14165550123", 1, "CA", phonenumber.NumberTypeFixedLineOrMobile},
+		{"US toll free", "800-555-0199", "US", "+18005550199", 1, "US", phonenumber.NumberTypeTollFree},
+		{"GB mobile national", "07911 123456", "GB", "+447911123456", 44, "GB", phonenumber.NumberTypeMobile},
+		{"GB landline with (0)", "+44 (0)20 7946 0958", "", "+442079460958", 44, "GB", phonenumber.NumberTypeFixedLine},
+		{"DE mobile 00 prefix", "0049 151 23456789", "", "+4915123456789", 49, "DE", phonenumber.NumberTypeMobile},
+		{"IL mobile", "054-998-2913", "IL", "+972549982913", 972, "IL", phonenumber.NumberTypeMobile},
+		{"RU trunk prefix 8", "8 (916) 123-45-67", "RU", "+79161234567", 7, "RU", phonenumber.NumberTypeMobile},
+		{"KZ shares calling code", "+7 701 123 4567", "", "+77011234567", 7, "KZ", phonenumber.NumberTypeMobile},
+		{"IT keeps leading zero", "+39 06 1234 5678", "", "+390612345678", 39, "IT", phonenumber.NumberTypeFixedLine},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			num, err := phonenumber.Parse(tc.input, tc.region)
+			require.NoError(t, err)
+			assert.Equal(t, tc.e164, num.E164())
+			assert.Equal(t, tc.countryCode, num.CountryCode)
+			assert.Equal(t, tc.numRegion, num.Region)
+			assert.Equal(t, tc.numType, num.Type)
+		})
+	}
+
+	errorCases := []struct {
+		name   string
+		input  string
+		region string
+		err    error
+	}{
+		{"empty", "  ", "US", phonenumber.ErrEmpty},
+		{"letters", "+1 415 CALL NOW", "US", phonenumber.ErrInvalidCharacters},
+		{"national without region", "415 555 2671", "", phonenumber.ErrMissingRegion},
+		{"unknown region", "415 555 2671", "XX", phonenumber.ErrUnknownRegion},
+		{"unknown calling code", "+999 1234 5678", "", phonenumber.ErrUnknownCountryCode},
+		{"wrong length", "+44 7911 12", "", phonenumber.ErrInvalidLength},
+		{"invalid NANP exchange", "+1 415 055 2671", "", phonenumber.ErrInvalidNumber},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := phonenumber.Parse(tc.input, tc.region)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestPhoneNumberAutoNormalization(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Write([]byte(`{"swapped":false,"checked_at":"2025-01-01T10:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	t.Run("should normalize formatted input when enabled", func(t *testing.T) {
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithPhoneNormalization("US"),
+		)
+
+		_, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: "(415) 740-0083"})
+		require.NoError(t, err)
+		assert.Equal(t, testPhoneNumbers.TMobileValid, received["phone_number"])
+	})
+
+	t.Run("should fall back to E.164 for calling codes without metadata", func(t *testing.T) {
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithPhoneNormalization("US"),
+		)
+
+		_, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: "+999 1234 5678"})
+		require.NoError(t, err)
+		assert.Equal(t, "+99912345678", received["phone_number"])
+	})
+
+	t.Run("should accept numbers that were valid E.164 before normalization", func(t *testing.T) {
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithPhoneNormalization("US"),
+		)
+
+		// Each of these passes ValidatePhoneNumber but is not covered by the numbering plan metadata
+		for input, want := range map[string]string{
+			"+441234567":      "+441234567",   // 7 digit GB number
+			"+44 1234 567":    "+441234567",   // The same number formatted
+			"+14150552671":    "+14150552671", // NANP exchange outside the metadata pattern
+			"+3712345678901":  "+3712345678901",
+			"+999 1234 56789": "+999123456789",
+		} {
+			require.NoError(t, glide.ValidatePhoneNumber(want), want)
+
+			_, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: input})
+			require.NoError(t, err, input)
+			assert.Equal(t, want, received["phone_number"], input)
+		}
+
+		// National numbers still have to match the default region's metadata
+		_, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: "415 055"})
+		requireGlideError(t, err, glide.ErrCodeValidationError, 0)
+	})
+
+	t.Run("should keep strict validation by default", func(t *testing.T) {
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+		)
+
+		_, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.WithSpaces})
+		require.Error(t, err)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, glide.ErrCodeValidationError, glideErr.Code)
+	})
+}
@@ -0,0 +1,102 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/GlideIdentity/glide-be-sdk-go/glidetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPLMNRegistry(t *testing.T) {
+	t.Run("should look up carrier by MCC/MNC", func(t *testing.T) {
+		info, ok := glide.LookupPLMN(testPLMN.TMobileUS.MCC, testPLMN.TMobileUS.MNC)
+		require.True(t, ok)
+		assert.Equal(t, "T-Mobile US", info.Name())
+		assert.Equal(t, "US", info.CountryISO)
+		assert.Equal(t, "United States", info.CountryName)
+
+		_, ok = glide.LookupPLMN(testPLMN.TestLab.MCC, testPLMN.TestLab.MNC)
+		assert.False(t, ok)
+	})
+
+	t.Run("should list networks by country and phone number", func(t *testing.T) {
+		networks := glide.PLMNsForCountry("DEU")
+		require.NotEmpty(t, networks)
+		for _, n := range networks {
+			assert.Equal(t, "262", n.MCC)
+		}
+
+		networks = glide.PLMNsForPhoneNumber(testPhoneNumbers.NonEligible)
+		require.NotEmpty(t, networks)
+		assert.Equal(t, "IL", networks[0].CountryISO)
+	})
+
+	t.Run("should ship a valid embedded dataset", func(t *testing.T) {
+		data, err := os.ReadFile("../../glide/data/plmn.csv")
+		require.NoError(t, err)
+		registry, err := glide.LoadPLMNRegistry(bytes.NewReader(data))
+		require.NoError(t, err)
+
+		rows := strings.Count(strings.TrimSpace(string(data)), "\n")
+		assert.Len(t, registry.ForCountry("US"), len(glide.PLMNsForCountry("US")))
+		assert.Greater(t, rows, 100)
+		for _, country := range []string{"US", "DE", "GB", "IL"} {
+			for _, n := range registry.ForCountry(country) {
+				assert.NotEmpty(t, n.Name(), "%s-%s has no name", n.MCC, n.MNC)
+				assert.NotEmpty(t, n.CountryName, "%s-%s has no country", n.MCC, n.MNC)
+			}
+		}
+	})
+
+	t.Run("should scope registry updates to a client", func(t *testing.T) {
+		registry := glide.NewPLMNRegistry()
+		require.NoError(t, registry.Register(glide.PLMNInfo{MCC: "999", MNC: "99", CountryISO: "US", Brand: "Telco Lab"}))
+		info, ok := registry.Lookup("999", "99")
+		require.True(t, ok)
+		assert.Equal(t, "Telco Lab", info.Name())
+		assert.Equal(t, "United States", info.CountryName)
+		_, ok = registry.Lookup(testPLMN.TMobileUS.MCC, testPLMN.TMobileUS.MNC)
+		assert.True(t, ok, "new registries start from the embedded dataset")
+
+		_, ok = glide.LookupPLMN("999", "99")
+		assert.False(t, ok, "registrations do not change the embedded dataset")
+
+		_, err := glide.LoadPLMNRegistry(strings.NewReader("mcc,mnc,iso,brand,operator\n31,260,US,Bad,Bad\n"))
+		requireGlideError(t, err, glide.ErrCodeValidationError, 0)
+
+		server := glidetest.NewServer()
+		t.Cleanup(server.Close)
+
+		lab := server.Client(glide.WithStrictPLMNValidation(true), glide.WithPLMNRegistry(registry))
+		req := &glide.PrepareRequest{UseCase: glide.UseCaseGetPhoneNumber, PLMN: &glide.PLMN{MCC: "999", MNC: "99"}}
+		_, err = lab.MagicAuth.Prepare(context.Background(), req)
+		require.NoError(t, err)
+
+		other := server.Client(glide.WithStrictPLMNValidation(true))
+		_, err = other.MagicAuth.Prepare(context.Background(), req)
+		requireGlideError(t, err, glide.ErrCodeValidationError, 0)
+	})
+
+	t.Run("should reject unknown PLMN in strict mode before calling the API", func(t *testing.T) {
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL("http://127.0.0.1:0"),
+			glide.WithStrictPLMNValidation(true),
+		)
+
+		_, err := client.MagicAuth.Prepare(context.Background(), &glide.PrepareRequest{
+			UseCase: glide.UseCaseGetPhoneNumber,
+			PLMN:    &glide.PLMN{MCC: "310", MNC: "999"},
+		})
+		require.Error(t, err)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, glide.ErrCodeValidationError, glideErr.Code)
+	})
+}
@@ -0,0 +1,212 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// quotaServer answers with scripted rate limit headers
+type quotaServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	headers map[string]string
+	status  int
+	calls   int32
+}
+
+func newQuotaServer(t *testing.T) *quotaServer {
+	s := &quotaServer{status: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.calls, 1)
+		s.mu.Lock()
+		for key, value := range s.headers {
+			w.Header().Set(key, value)
+		}
+		status := s.status
+		s.mu.Unlock()
+
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"swapped":false,"checked_at":"2025-01-01T10:00:00Z"}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *quotaServer) respond(status int, headers map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+	s.headers = headers
+}
+
+func TestServerQuota(t *testing.T) {
+	ctx := context.Background()
+	req := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+
+	t.Run("should expose quota headers and warn once when running low", func(t *testing.T) {
+		server := newQuotaServer(t)
+		logger := &recordingLogger{}
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithLogger(logger),
+			glide.WithQuotaWarningThreshold(0.2),
+			glide.WithQuotaPacing(false),
+		)
+		assert.False(t, client.Quota().Known())
+
+		reset := time.Now().Add(time.Hour).Truncate(time.Second)
+		server.respond(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "100",
+			"X-RateLimit-Remaining": "42",
+			"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+		})
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+
+		quota := client.Quota()
+		require.True(t, quota.Known())
+		assert.Equal(t, 100, quota.Limit)
+		assert.Equal(t, 42, quota.Remaining)
+		assert.True(t, reset.Equal(quota.Reset))
+		assert.False(t, logger.contains("WARN: API quota running low"))
+
+		server.respond(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "100",
+			"X-RateLimit-Remaining": "15",
+			"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+		})
+		_, err = client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		_, err = client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, 1, logger.count("WARN: API quota running low"))
+	})
+
+	t.Run("should wait for the window to reset when the quota is exhausted", func(t *testing.T) {
+		server := newQuotaServer(t)
+		client := glide.New(glide.WithAPIKey("test-key"), glide.WithBaseURL(server.URL))
+
+		server.respond(http.StatusOK, map[string]string{
+			"RateLimit-Limit":     "10",
+			"RateLimit-Remaining": "0",
+			"RateLimit-Reset":     "30",
+		})
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+
+		_, err = client.SimSwap.Check(ctx, req, glide.WithCallTimeout(100*time.Millisecond))
+		assertRateLimited(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&server.calls), "exhausted quota must not reach the server")
+
+		unpaced := glide.New(glide.WithAPIKey("test-key"), glide.WithBaseURL(server.URL), glide.WithQuotaPacing(false))
+		_, err = unpaced.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail fast when the reset is beyond the client timeout", func(t *testing.T) {
+		server := newQuotaServer(t)
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithTimeout(time.Second),
+		)
+
+		server.respond(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "10",
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     "3600",
+		})
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = client.SimSwap.Check(context.Background(), req)
+		assertRateLimited(t, err)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+		assert.InDelta(t, 3600, err.(*glide.Error).Details["retry_after"], 1)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&server.calls))
+	})
+
+	t.Run("should cap pacing at the call timeout", func(t *testing.T) {
+		server := newQuotaServer(t)
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithTimeout(100*time.Millisecond),
+		)
+
+		server.respond(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "10",
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     "1",
+		})
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+
+		// The reset is beyond the client timeout but within the call's own timeout
+		server.respond(http.StatusOK, nil)
+		_, err = client.SimSwap.Check(ctx, req, glide.WithCallTimeout(5*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&server.calls))
+	})
+
+	t.Run("should track the quota of each operation separately", func(t *testing.T) {
+		server := newQuotaServer(t)
+		client := glide.New(glide.WithAPIKey("test-key"), glide.WithBaseURL(server.URL))
+
+		server.respond(http.StatusOK, map[string]string{
+			"X-RateLimit-Limit":     "10",
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     "3600",
+		})
+		_, err := client.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, 0, client.QuotaFor("/sim-swap/check").Remaining)
+		assert.False(t, client.QuotaFor("/number-verify/verify").Known())
+
+		// An exhausted operation does not hold back the others
+		server.respond(http.StatusOK, nil)
+		_, err = client.KYC.Match(ctx, &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, Name: "Jane"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&server.calls))
+	})
+
+	t.Run("should honor Retry-After on 429", func(t *testing.T) {
+		server := newQuotaServer(t)
+		server.respond(http.StatusTooManyRequests, map[string]string{"Retry-After": "1"})
+
+		client := glide.New(glide.WithAPIKey("test-key"), glide.WithBaseURL(server.URL), glide.WithRetry(0, 0))
+		_, err := client.SimSwap.Check(ctx, req)
+		assertRateLimited(t, err)
+		assert.Equal(t, float64(1), err.(*glide.Error).Details["retry_after"])
+		assert.False(t, client.Quota().RetryAfter.IsZero())
+
+		retrying := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(1, time.Millisecond),
+			glide.WithQuotaPacing(false), // Retry-After is honored even without pacing
+		)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			server.respond(http.StatusOK, nil)
+		}()
+		start := time.Now()
+		_, err = retrying.SimSwap.Check(ctx, req)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	})
+}
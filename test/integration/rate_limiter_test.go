@@ -0,0 +1,220 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTokenBucketStore is an in-memory stand-in for a Redis token bucket
+type fakeTokenBucketStore struct {
+	mu      sync.Mutex
+	buckets map[string]*fakeBucket
+}
+
+type fakeBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newFakeTokenBucketStore() *fakeTokenBucketStore {
+	return &fakeTokenBucketStore{buckets: make(map[string]*fakeBucket)}
+}
+
+func (s *fakeTokenBucketStore) Take(ctx context.Context, key string, limit glide.RateLimit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	interval := limit.Period / time.Duration(limit.Rate)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &fakeBucket{tokens: float64(limit.Rate), last: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens += float64(now.Sub(b.last)) / float64(interval)
+	if b.tokens > float64(limit.Rate) {
+		b.tokens = float64(limit.Rate)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	return false, time.Duration((1 - b.tokens) * float64(interval)), nil
+}
+
+func (s *fakeTokenBucketStore) Refund(ctx context.Context, key string, limit glide.RateLimit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok := s.buckets[key]; ok && b.tokens+1 <= float64(limit.Rate) {
+		b.tokens++
+	}
+	return nil
+}
+
+func (s *fakeTokenBucketStore) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key := range s.buckets {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func newRateLimitTestServer(t *testing.T, calls *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		w.Write([]byte(`{"swapped":false,"checked_at":"2025-01-01T10:00:00Z"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func assertRateLimited(t *testing.T, err error) {
+	t.Helper()
+	require.Error(t, err)
+	glideErr, ok := err.(*glide.Error)
+	require.True(t, ok)
+	assert.Equal(t, glide.ErrCodeRateLimitExceeded, glideErr.Code)
+}
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	simSwapReq := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+	kycReq := &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, Name: "Jane"}
+
+	t.Run("should apply per-operation limits on top of the global limit", func(t *testing.T) {
+		var calls int32
+		server := newRateLimitTestServer(t, &calls)
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(0, 0),
+			glide.WithRateLimit(100, time.Second),
+			glide.WithOperationRateLimit("/kyc", 1, time.Minute),
+		)
+
+		_, err := client.KYC.Match(ctx, kycReq)
+		require.NoError(t, err)
+		_, err = client.KYC.Match(ctx, kycReq, glide.WithCallTimeout(50*time.Millisecond))
+		assertRateLimited(t, err)
+
+		for i := 0; i < 5; i++ {
+			_, err = client.SimSwap.Check(ctx, simSwapReq)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(6), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("should share the quota between clients through the token bucket store", func(t *testing.T) {
+		var calls int32
+		server := newRateLimitTestServer(t, &calls)
+		store := newFakeTokenBucketStore()
+
+		newClient := func() *glide.Client {
+			limiter := glide.NewDistributedRateLimiter(store, "", glide.RateLimits{
+				Global: &glide.RateLimit{Rate: 2, Period: time.Minute},
+			})
+			return glide.New(
+				glide.WithAPIKey("test-key"),
+				glide.WithBaseURL(server.URL),
+				glide.WithRetry(0, 0),
+				glide.WithRateLimiter(limiter),
+			)
+		}
+		replicaA, replicaB := newClient(), newClient()
+
+		_, err := replicaA.SimSwap.Check(ctx, simSwapReq)
+		require.NoError(t, err)
+		_, err = replicaB.SimSwap.Check(ctx, simSwapReq)
+		require.NoError(t, err)
+		_, err = replicaA.SimSwap.Check(ctx, simSwapReq, glide.WithCallTimeout(50*time.Millisecond))
+		assertRateLimited(t, err)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+		assert.Equal(t, []string{glide.DefaultRateLimitKeyPrefix + "global"}, store.keys())
+	})
+
+	t.Run("should wait for the store to refill", func(t *testing.T) {
+		store := newFakeTokenBucketStore()
+		limiter := glide.NewDistributedRateLimiter(store, "tenant-a:", glide.RateLimits{
+			Operations: map[string]glide.RateLimit{
+				"/kyc-match": {Rate: 1, Period: 100 * time.Millisecond},
+			},
+		})
+
+		require.NoError(t, limiter.Wait(ctx, "/kyc-match/match"))
+		start := time.Now()
+		require.NoError(t, limiter.Wait(ctx, "/kyc-match/match"))
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+		// Operations without a limit are not throttled
+		require.NoError(t, limiter.Wait(ctx, "/sim-swap/check"))
+		assert.Equal(t, []string{"tenant-a:op:/kyc-match"}, store.keys())
+	})
+
+	t.Run("should return a context error when cancelled while waiting", func(t *testing.T) {
+		var calls int32
+		server := newRateLimitTestServer(t, &calls)
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(0, 0),
+			glide.WithRateLimit(1, time.Minute),
+		)
+		_, err := client.SimSwap.Check(ctx, simSwapReq)
+		require.NoError(t, err)
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(20*time.Millisecond, cancel)
+		_, err = client.SimSwap.Check(cancelCtx, simSwapReq)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.Canceled)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.False(t, glideErr.IsRetryable())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("should not lose the operation token when the global limit fails", func(t *testing.T) {
+		limits := glide.RateLimits{
+			Global:     &glide.RateLimit{Rate: 1, Period: 200 * time.Millisecond},
+			Operations: map[string]glide.RateLimit{"/kyc": {Rate: 1, Period: time.Minute}},
+		}
+		store := newFakeTokenBucketStore()
+		limiters := map[string]glide.RateLimiter{
+			"local":       glide.NewLocalRateLimiter(limits),
+			"distributed": glide.NewDistributedRateLimiter(store, "", limits),
+		}
+		for name, limiter := range limiters {
+			require.NoError(t, limiter.Wait(ctx, "/sim-swap/check"), name)
+			shortCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			require.Error(t, limiter.Wait(shortCtx, "/kyc/match"), name)
+			cancel()
+
+			// Only the global bucket needs to refill, the operation token was returned
+			refillCtx, cancel := context.WithTimeout(ctx, time.Second)
+			require.NoError(t, limiter.Wait(refillCtx, "/kyc/match"), name)
+			cancel()
+		}
+	})
+
+	t.Run("should express fast rates in microseconds", func(t *testing.T) {
+		assert.Equal(t, int64(500), glide.RateLimit{Rate: 2000, Period: time.Second}.IntervalMicros())
+		assert.Equal(t, int64(1), glide.RateLimit{Rate: 10000000, Period: time.Second}.IntervalMicros())
+		assert.Contains(t, glide.TokenBucketScript, "* 1000000")
+	})
+}
@@ -0,0 +1,82 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseMeta(t *testing.T) {
+	ctx := context.Background()
+	req := &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid}
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-Request-ID", "req-abc")
+		w.Header().Set("X-Custom", "value")
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path == "/kyc-match/match" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"code":"UNPROCESSABLE_ENTITY","message":"No match possible"}`))
+			return
+		}
+		w.Write([]byte(`{"swapped":false,"checked_at":"2025-01-01T10:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := glide.New(
+		glide.WithAPIKey("test-key"),
+		glide.WithBaseURL(server.URL),
+		glide.WithRetry(2, time.Millisecond),
+		glide.WithRateLimit(100, time.Second),
+	)
+
+	t.Run("should report metadata for successful calls", func(t *testing.T) {
+		var meta glide.ResponseMeta
+		_, err := client.SimSwap.Check(ctx, req, glide.WithResponseMeta(&meta), glide.WithIdempotencyKey("key-1"))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, meta.StatusCode)
+		assert.Equal(t, "req-abc", meta.RequestID)
+		assert.Equal(t, "value", meta.Header.Get("X-Custom"))
+		assert.Equal(t, "key-1", meta.IdempotencyKey)
+		assert.Equal(t, 2, meta.Attempts)
+		assert.Greater(t, meta.Latency, time.Duration(0))
+		assert.LessOrEqual(t, meta.RateLimitWait, meta.Latency)
+	})
+
+	t.Run("should report metadata and request ID for failed calls", func(t *testing.T) {
+		var meta glide.ResponseMeta
+		_, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{PhoneNumber: testPhoneNumbers.TMobileValid, Name: "Jane"},
+			glide.WithResponseMeta(&meta))
+		require.Error(t, err)
+
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, "req-abc", glideErr.RequestID, "request ID header fills in the error")
+		assert.Equal(t, http.StatusUnprocessableEntity, meta.StatusCode)
+		assert.Equal(t, "req-abc", meta.RequestID)
+		assert.Equal(t, 1, meta.Attempts)
+		assert.NotEmpty(t, meta.IdempotencyKey, "a key is generated by default")
+		assert.Equal(t, meta.IdempotencyKey, glideErr.IdempotencyKey)
+	})
+
+	t.Run("should leave metadata empty when validation fails locally", func(t *testing.T) {
+		var meta glide.ResponseMeta
+		_, err := client.SimSwap.Check(ctx, &glide.SimSwapCheckRequest{}, glide.WithResponseMeta(&meta))
+		require.Error(t, err)
+		assert.Equal(t, 0, meta.Attempts)
+		assert.Equal(t, 0, meta.StatusCode)
+	})
+}
@@ -0,0 +1,175 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GlideIdentity/glide-be-sdk-go/glide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuredValidationErrors(t *testing.T) {
+	t.Run("should report every invalid PLMN field", func(t *testing.T) {
+		err := glide.ValidatePLMN(&glide.PLMN{MCC: "31", MNC: "2"})
+		require.Error(t, err)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, glide.ErrCodeValidationError, glideErr.Code)
+		assert.Equal(t, "MCC must be exactly 3 digits", glideErr.Message, "the message is the first problem")
+
+		violations := glideErr.Violations()
+		require.Len(t, violations, 2)
+		assert.Equal(t, "plmn.mcc", violations[0].Field)
+		assert.Equal(t, glide.RuleFormat, violations[0].Rule)
+		assert.Equal(t, "plmn.mnc", violations[1].Field)
+	})
+
+	t.Run("should use missing parameters code only for missing parameters", func(t *testing.T) {
+		err := glide.ValidateUseCaseRequirements(glide.UseCaseVerifyPhoneNumber, "", nil)
+		require.Error(t, err)
+		assert.Equal(t, glide.ErrCodeMissingParameters, err.(*glide.Error).Code)
+
+		// Incomplete consent data is invalid rather than missing
+		err = glide.ValidateConsentData(&glide.ConsentData{PolicyLink: "https://example.com/privacy"})
+		require.Error(t, err)
+		glideErr := err.(*glide.Error)
+		assert.Equal(t, glide.ErrCodeValidationError, glideErr.Code)
+
+		fields := map[string]string{}
+		for _, v := range glideErr.Violations() {
+			fields[v.Field] = v.Rule
+		}
+		assert.Equal(t, map[string]string{
+			"consent_data.consent_text": glide.RuleRequired,
+			"consent_data.policy_text":  glide.RuleRequired,
+		}, fields)
+	})
+
+	t.Run("should index boundary points in field paths", func(t *testing.T) {
+		err := glide.ValidateLocationArea(&glide.LocationArea{
+			AreaType: glide.AreaTypePolygon,
+			Boundary: []glide.Point{{Latitude: 10, Longitude: 10}, {Latitude: 11, Longitude: 10}, {Latitude: 95, Longitude: 200}},
+		})
+		require.Error(t, err)
+		violations := err.(*glide.Error).Violations()
+		require.Len(t, violations, 2)
+		assert.Equal(t, "area.boundary[2].latitude", violations[0].Field)
+		assert.Equal(t, "area.boundary[2].longitude", violations[1].Field)
+	})
+
+	t.Run("should collect all prepare request problems before calling the API", func(t *testing.T) {
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL("http://127.0.0.1:0"),
+		)
+
+		_, err := client.MagicAuth.Prepare(context.Background(), &glide.PrepareRequest{
+			UseCase:     glide.UseCaseGetPhoneNumber,
+			PhoneNumber: testPhoneNumbers.TMobileValid,
+			ConsentData: &glide.ConsentData{ConsentText: "I agree", PolicyLink: "example.com", PolicyText: "Policy"},
+		})
+		require.Error(t, err)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, glide.ErrCodeValidationError, glideErr.Code)
+
+		var fields []string
+		for _, v := range glideErr.Violations() {
+			fields = append(fields, v.Field+":"+v.Rule)
+		}
+		assert.Equal(t, []string{
+			"phone_number:" + glide.RuleForbidden,
+			"plmn:" + glide.RuleRequired,
+			"consent_data.policy_link:" + glide.RuleURL,
+		}, fields)
+	})
+
+	t.Run("should expose the ValidationError through errors.As", func(t *testing.T) {
+		err := glide.ValidatePLMN(&glide.PLMN{MCC: "31", MNC: "2"})
+
+		var validationErr *glide.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.Len(t, validationErr.Violations, 2)
+		assert.Equal(t, "plmn.mcc", validationErr.Violations[0].Field)
+		assert.Equal(t, err.(*glide.Error).Violations(), validationErr.Violations)
+
+		var glideErr *glide.Error
+		require.True(t, errors.As(err, &glideErr))
+		assert.Equal(t, glide.ErrCodeValidationError, glideErr.Code)
+
+		// Errors without field information have no ValidationError
+		assert.False(t, errors.As(glide.NewError(glide.ErrCodeBadRequest, "Bad request"), &validationErr))
+	})
+
+	t.Run("should collect all KYC request problems before calling the API", func(t *testing.T) {
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL("http://127.0.0.1:0"),
+			glide.WithKYCNormalization(true),
+		)
+		ctx := context.Background()
+
+		_, err := client.KYC.Match(ctx, &glide.KYCMatchRequest{
+			PhoneNumber: "4157400083",
+			BirthDate:   "1990-02-30",
+			Gender:      "unknown",
+			Nationality: "Atlantis",
+		})
+		assert.ElementsMatch(t, []string{"phone_number", "birth_date", "gender", "nationality"}, violationFields(t, err))
+
+		_, err = client.KYC.Match(ctx, &glide.KYCMatchRequest{})
+		glideErr := requireGlideError(t, err, glide.ErrCodeMissingParameters, 0)
+		require.Len(t, glideErr.Violations(), 2)
+		assert.Equal(t, "phone_number", glideErr.Violations()[0].Field)
+		assert.Equal(t, "match_fields", glideErr.Violations()[1].Field)
+
+		_, err = client.KYC.AgeVerify(ctx, &glide.KYCAgeVerifyRequest{AgeThreshold: 150, BirthDate: "15/01/1990"})
+		glideErr = requireGlideError(t, err, glide.ErrCodeValidationError, 0)
+		var validationErr *glide.ValidationError
+		require.True(t, errors.As(glideErr, &validationErr))
+		rules := map[string]string{}
+		for _, v := range validationErr.Violations {
+			rules[v.Field] = v.Rule
+		}
+		assert.Equal(t, map[string]string{
+			"phone_number":  glide.RuleRequired,
+			"age_threshold": glide.RuleRange,
+			"birth_date":    glide.RuleFormat,
+		}, rules)
+	})
+
+	t.Run("should decode violations returned by the server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"code":    glide.ErrCodeValidationError,
+				"message": "Invalid request",
+				"details": map[string]interface{}{
+					"violations": []map[string]string{
+						{"field": "phone_number", "rule": "format", "message": "Unsupported number range"},
+					},
+				},
+			})
+		}))
+		defer server.Close()
+
+		client := glide.New(
+			glide.WithAPIKey("test-key"),
+			glide.WithBaseURL(server.URL),
+			glide.WithRetry(0, 0),
+		)
+
+		_, err := client.SimSwap.Check(context.Background(), &glide.SimSwapCheckRequest{PhoneNumber: testPhoneNumbers.TMobileValid})
+		require.Error(t, err)
+		glideErr, ok := err.(*glide.Error)
+		require.True(t, ok)
+		assert.Equal(t, []glide.FieldViolation{
+			{Field: "phone_number", Rule: "format", Message: "Unsupported number range"},
+		}, glideErr.Violations())
+	})
+}